@@ -0,0 +1,595 @@
+// Package allowlist provides generators of firewall allowlists for updown.io
+// monitoring nodes
+package allowlist
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/essentialkaos/updown"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	FORMAT_CIDR      Format = "cidr"
+	FORMAT_IPTABLES  Format = "iptables"
+	FORMAT_IP6TABLES Format = "ip6tables"
+	FORMAT_NFTABLES  Format = "nftables"
+	FORMAT_NGINX     Format = "nginx"
+	FORMAT_K8S       Format = "k8s"
+	FORMAT_AWS       Format = "aws"
+)
+
+// DEFAULT_NAME is default name of chain, set or policy
+const DEFAULT_NAME = "updown"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Format is allowlist format
+type Format string
+
+// Entries is a slice with IP addresses of monitoring nodes
+type Entries []*Entry
+
+// Entry contains info about node address
+type Entry struct {
+	Addr netip.Addr // IP address
+	Node string     // Node name (optional)
+}
+
+// Options contains allowlist generation options
+type Options struct {
+	// Name is name of iptables chain, nftables set prefix or NetworkPolicy
+	// (DEFAULT_NAME is used if empty)
+	Name string
+
+	// Namespace is Kubernetes namespace of NetworkPolicy
+	Namespace string
+
+	// Ports is list of allowed TCP ports. If empty, all ports are allowed.
+	Ports []int
+
+	// PodSelector is Kubernetes pod selector labels. If empty, policy is
+	// applied to all pods in namespace.
+	PodSelector map[string]string
+}
+
+// Changes contains difference between two lists
+type Changes struct {
+	Added   Entries
+	Removed Entries
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrUnknownFormat = errors.New("Unknown allowlist format")
+	ErrNilWriter     = errors.New("Writer is nil")
+	ErrEmptyList     = errors.New("Allowlist is empty")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Formats is a slice with all supported formats
+var Formats = []Format{
+	FORMAT_CIDR, FORMAT_IPTABLES, FORMAT_IP6TABLES, FORMAT_NFTABLES,
+	FORMAT_NGINX, FORMAT_K8S, FORMAT_AWS,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// FromNodes creates list from monitoring nodes
+func FromNodes(nodes updown.Nodes) Entries {
+	var result Entries
+
+	names := make([]string, 0, len(nodes))
+
+	for name := range nodes {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		node := nodes[name]
+
+		if node == nil {
+			continue
+		}
+
+		for _, ip := range []string{node.IP, node.IPv6} {
+			addr, err := netip.ParseAddr(ip)
+
+			if err == nil {
+				result = append(result, &Entry{Addr: addr.Unmap(), Node: name})
+			}
+		}
+	}
+
+	return result.normalize()
+}
+
+// FromIPs creates list from IP addresses (e.g. result of GetNodesIPs)
+func FromIPs(ips []string) (Entries, error) {
+	var result Entries
+
+	for _, ip := range ips {
+		addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't parse IP address %q: %w", ip, err)
+		}
+
+		result = append(result, &Entry{Addr: addr.Unmap()})
+	}
+
+	return result.normalize(), nil
+}
+
+// Parse parses list in CIDR format (e.g. previously generated list). Empty
+// lines and comments are ignored, node name can be set in comment after
+// address.
+func Parse(r io.Reader) (Entries, error) {
+	var result Entries
+
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		text, comment, _ := strings.Cut(scanner.Text(), "#")
+		text = strings.TrimSpace(text)
+
+		if text == "" {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(text)
+
+		if err != nil {
+			addr, aerr := netip.ParseAddr(text)
+
+			if aerr != nil {
+				return nil, fmt.Errorf("Can't parse line %d: %w", line, err)
+			}
+
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+
+		if !prefix.IsSingleIP() {
+			return nil, fmt.Errorf("Can't parse line %d: only single addresses are supported", line)
+		}
+
+		result = append(result, &Entry{
+			Addr: prefix.Addr().Unmap(),
+			Node: strings.TrimSpace(comment),
+		})
+	}
+
+	if scanner.Err() != nil {
+		return nil, fmt.Errorf("Can't read list: %w", scanner.Err())
+	}
+
+	return result.normalize(), nil
+}
+
+// Diff returns difference between previous and current lists
+func Diff(prev, cur Entries) *Changes {
+	changes := &Changes{}
+
+	for _, e := range cur {
+		if !prev.Contains(e.Addr) {
+			changes.Added = append(changes.Added, e)
+		}
+	}
+
+	for _, e := range prev {
+		if !cur.Contains(e.Addr) {
+			changes.Removed = append(changes.Removed, e)
+		}
+	}
+
+	return changes
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IPv4 returns IPv4 addresses
+func (l Entries) IPv4() Entries {
+	return l.filter(func(e *Entry) bool { return e.Addr.Is4() })
+}
+
+// IPv6 returns IPv6 addresses
+func (l Entries) IPv6() Entries {
+	return l.filter(func(e *Entry) bool { return e.Addr.Is6() })
+}
+
+// Contains returns true if list contains given address
+func (l Entries) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return slices.ContainsFunc(l, func(e *Entry) bool {
+		return e.Addr == addr
+	})
+}
+
+// Write writes allowlist in given format to writer. For k8s and aws formats
+// ErrEmptyList is returned if list is empty, because rules without sources
+// allow traffic from anywhere.
+func (l Entries) Write(w io.Writer, format Format, options *Options) error {
+	switch {
+	case w == nil:
+		return ErrNilWriter
+	case len(l) == 0 && (format == FORMAT_K8S || format == FORMAT_AWS):
+		return ErrEmptyList
+	}
+
+	if options == nil {
+		options = &Options{}
+	}
+
+	switch format {
+	case FORMAT_CIDR:
+		return l.writeCIDR(w)
+	case FORMAT_IPTABLES:
+		return l.IPv4().writeIptables(w, "iptables", options)
+	case FORMAT_IP6TABLES:
+		return l.IPv6().writeIptables(w, "ip6tables", options)
+	case FORMAT_NFTABLES:
+		return l.writeNftables(w, options)
+	case FORMAT_NGINX:
+		return l.writeNginx(w)
+	case FORMAT_K8S:
+		return l.writeNetworkPolicy(w, options)
+	case FORMAT_AWS:
+		return l.writeAWS(w, options)
+	}
+
+	return ErrUnknownFormat
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsEmpty returns true if there are no changes
+func (c *Changes) IsEmpty() bool {
+	return c == nil || (len(c.Added) == 0 && len(c.Removed) == 0)
+}
+
+// String returns changes in unified diff-like format
+func (c *Changes) String() string {
+	if c.IsEmpty() {
+		return ""
+	}
+
+	var buf strings.Builder
+
+	for _, e := range c.Removed {
+		fmt.Fprintf(&buf, "- %s\n", e)
+	}
+
+	for _, e := range c.Added {
+		fmt.Fprintf(&buf, "+ %s\n", e)
+	}
+
+	return buf.String()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CIDR returns address in CIDR notation
+func (e *Entry) CIDR() string {
+	return netip.PrefixFrom(e.Addr, e.Addr.BitLen()).String()
+}
+
+// String returns entry as string
+func (e *Entry) String() string {
+	if e.Node == "" {
+		return e.CIDR()
+	}
+
+	return e.CIDR() + " # " + e.Node
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getName returns name of chain, set or policy
+func (o *Options) getName() string {
+	if o.Name == "" {
+		return DEFAULT_NAME
+	}
+
+	return o.Name
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// writeCIDR writes list in CIDR format
+func (l Entries) writeCIDR(w io.Writer) error {
+	for _, e := range l {
+		_, err := fmt.Fprintln(w, e)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeIptables writes list as iptables/ip6tables commands
+func (l Entries) writeIptables(w io.Writer, cmd string, options *Options) error {
+	chain := options.getName()
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "%s -N %s\n", cmd, chain)
+
+	for _, e := range l {
+		rule := fmt.Sprintf("%s -A %s -s %s", cmd, chain, e.CIDR())
+
+		switch len(options.Ports) {
+		case 0:
+			// all ports
+		case 1:
+			rule += " -p tcp --dport " + strconv.Itoa(options.Ports[0])
+		default:
+			rule += " -p tcp -m multiport --dports " + joinPorts(options.Ports, ",")
+		}
+
+		rule += " -j ACCEPT"
+
+		if e.Node != "" {
+			rule += fmt.Sprintf(" -m comment --comment %q", "updown "+e.Node)
+		}
+
+		fmt.Fprintln(bw, rule)
+	}
+
+	return bw.Flush()
+}
+
+// writeNftables writes list as nftables sets
+func (l Entries) writeNftables(w io.Writer, options *Options) error {
+	name := options.getName()
+	bw := bufio.NewWriter(w)
+
+	for i, set := range []Entries{l.IPv4(), l.IPv6()} {
+		typ, suffix := "ipv4_addr", "ipv4"
+
+		if i == 1 {
+			typ, suffix = "ipv6_addr", "ipv6"
+		}
+
+		if i != 0 {
+			fmt.Fprintln(bw)
+		}
+
+		fmt.Fprintf(bw, "set %s_%s {\n", name, suffix)
+		fmt.Fprintf(bw, "  type %s\n", typ)
+
+		if len(set) != 0 {
+			fmt.Fprintln(bw, "  elements = {")
+
+			for j, e := range set {
+				sep := ","
+
+				if j == len(set)-1 {
+					sep = ""
+				}
+
+				fmt.Fprintf(bw, "    %s%s\n", e.Addr, sep)
+			}
+
+			fmt.Fprintln(bw, "  }")
+		}
+
+		fmt.Fprintln(bw, "}")
+	}
+
+	return bw.Flush()
+}
+
+// writeNginx writes list as nginx allow directives
+func (l Entries) writeNginx(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	for _, e := range l {
+		if e.Node == "" {
+			fmt.Fprintf(bw, "allow %s;\n", e.Addr)
+		} else {
+			fmt.Fprintf(bw, "allow %s; # %s\n", e.Addr, e.Node)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// writeNetworkPolicy writes list as Kubernetes NetworkPolicy YAML
+func (l Entries) writeNetworkPolicy(w io.Writer, options *Options) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "apiVersion: networking.k8s.io/v1")
+	fmt.Fprintln(bw, "kind: NetworkPolicy")
+	fmt.Fprintln(bw, "metadata:")
+	fmt.Fprintf(bw, "  name: %s\n", quoteYAML(options.getName()))
+
+	if options.Namespace != "" {
+		fmt.Fprintf(bw, "  namespace: %s\n", quoteYAML(options.Namespace))
+	}
+
+	fmt.Fprintln(bw, "spec:")
+
+	if len(options.PodSelector) == 0 {
+		fmt.Fprintln(bw, "  podSelector: {}")
+	} else {
+		fmt.Fprintln(bw, "  podSelector:")
+		fmt.Fprintln(bw, "    matchLabels:")
+
+		for _, k := range getSortedKeys(options.PodSelector) {
+			fmt.Fprintf(bw, "      %s: %s\n", quoteYAML(k), quoteYAML(options.PodSelector[k]))
+		}
+	}
+
+	fmt.Fprintln(bw, "  policyTypes:")
+	fmt.Fprintln(bw, "    - Ingress")
+	fmt.Fprintln(bw, "  ingress:")
+	fmt.Fprintln(bw, "    - from:")
+
+	for _, e := range l {
+		fmt.Fprintln(bw, "        - ipBlock:")
+		fmt.Fprintf(bw, "            cidr: %s\n", e.CIDR())
+	}
+
+	if len(options.Ports) != 0 {
+		fmt.Fprintln(bw, "      ports:")
+
+		for _, p := range options.Ports {
+			fmt.Fprintln(bw, "        - protocol: TCP")
+			fmt.Fprintf(bw, "          port: %d\n", p)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// writeAWS writes list as AWS security group IP permissions JSON (can be used
+// with "aws ec2 authorize-security-group-ingress --ip-permissions")
+func (l Entries) writeAWS(w io.Writer, options *Options) error {
+	type ipRange struct {
+		CidrIP      string `json:"CidrIp"`
+		Description string `json:"Description,omitempty"`
+	}
+
+	type ipv6Range struct {
+		CidrIPv6    string `json:"CidrIpv6"`
+		Description string `json:"Description,omitempty"`
+	}
+
+	type permission struct {
+		IPProtocol string      `json:"IpProtocol"`
+		FromPort   *int        `json:"FromPort,omitempty"`
+		ToPort     *int        `json:"ToPort,omitempty"`
+		IPRanges   []ipRange   `json:"IpRanges,omitempty"`
+		IPv6Ranges []ipv6Range `json:"Ipv6Ranges,omitempty"`
+	}
+
+	base := permission{IPProtocol: "-1"}
+
+	for _, e := range l {
+		desc := options.getName()
+
+		if e.Node != "" {
+			desc += " " + e.Node
+		}
+
+		if e.Addr.Is4() {
+			base.IPRanges = append(base.IPRanges, ipRange{e.CIDR(), desc})
+		} else {
+			base.IPv6Ranges = append(base.IPv6Ranges, ipv6Range{e.CIDR(), desc})
+		}
+	}
+
+	permissions := []permission{base}
+
+	if len(options.Ports) != 0 {
+		permissions = nil
+
+		for _, port := range options.Ports {
+			p := base
+			p.IPProtocol = "tcp"
+			p.FromPort, p.ToPort = &port, &port
+			permissions = append(permissions, p)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(permissions)
+}
+
+// filter returns entries which match given function
+func (l Entries) filter(fn func(e *Entry) bool) Entries {
+	var result Entries
+
+	for _, e := range l {
+		if fn(e) {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+// normalize sorts list and removes duplicates
+func (l Entries) normalize() Entries {
+	slices.SortStableFunc(l, func(a, b *Entry) int {
+		return a.Addr.Compare(b.Addr)
+	})
+
+	return slices.CompactFunc(l, func(a, b *Entry) bool {
+		return a.Addr == b.Addr
+	})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// joinPorts joins ports using given separator
+func joinPorts(ports []int, sep string) string {
+	var result []string
+
+	for _, p := range ports {
+		result = append(result, strconv.Itoa(p))
+	}
+
+	return strings.Join(result, sep)
+}
+
+// quoteYAML quotes YAML string value if required
+func quoteYAML(value string) string {
+	_, err := strconv.ParseFloat(value, 64)
+
+	if value != "" && err != nil && !isYAMLKeyword(value) &&
+		strings.Trim(value, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./") == "" {
+		return value
+	}
+
+	data, _ := json.Marshal(value)
+
+	return string(data)
+}
+
+// isYAMLKeyword returns true if given value has special meaning in YAML
+func isYAMLKeyword(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~":
+		return true
+	}
+
+	return false
+}
+
+// getSortedKeys returns sorted map keys
+func getSortedKeys(m map[string]string) []string {
+	var result []string
+
+	for k := range m {
+		result = append(result, k)
+	}
+
+	slices.Sort(result)
+
+	return result
+}
@@ -0,0 +1,255 @@
+package allowlist
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/essentialkaos/updown"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type AllowlistSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&AllowlistSuite{})
+
+var testNodes = updown.Nodes{
+	"lan": {IP: "91.121.222.175", IPv6: "2001:41d0:2:85af::1"},
+	"fra": {IP: "104.238.159.87", IPv6: "2001:19f0:6c01:145::1"},
+	"mia": {IP: "104.238.136.194"},
+	"bad": {IP: "unknown"},
+	"nil": nil,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *AllowlistSuite) TestLists(c *C) {
+	l := FromNodes(testNodes)
+
+	c.Assert(l, HasLen, 5)
+	c.Assert(l[0].String(), Equals, "91.121.222.175/32 # lan")
+	c.Assert(l[1].String(), Equals, "104.238.136.194/32 # mia")
+	c.Assert(l[4].String(), Equals, "2001:41d0:2:85af::1/128 # lan")
+	c.Assert(l.IPv4(), HasLen, 3)
+	c.Assert(l.IPv6(), HasLen, 2)
+	c.Assert(l.Contains(netip.MustParseAddr("::ffff:104.238.159.87")), Equals, true)
+	c.Assert(l.Contains(netip.MustParseAddr("1.1.1.1")), Equals, false)
+
+	l, err := FromIPs([]string{"1.1.1.1", " 1.1.1.1", "::1"})
+	c.Assert(err, IsNil)
+	c.Assert(l, HasLen, 2)
+	c.Assert(l[0].String(), Equals, "1.1.1.1/32")
+
+	_, err = FromIPs([]string{"1.1.1"})
+	c.Assert(err, ErrorMatches, `Can't parse IP address "1.1.1": .*`)
+
+	l, err = Parse(strings.NewReader("# updown nodes\n\n1.1.1.1/32 # lan\n2.2.2.2\n::1/128\n"))
+	c.Assert(err, IsNil)
+	c.Assert(l, HasLen, 3)
+	c.Assert(l[0].Node, Equals, "lan")
+
+	_, err = Parse(strings.NewReader("1.1.1.1\nabcd\n"))
+	c.Assert(err, ErrorMatches, "Can't parse line 2: .*")
+
+	_, err = Parse(strings.NewReader("1.1.1.0/24\n"))
+	c.Assert(err, ErrorMatches, "Can't parse line 1: only single addresses are supported")
+
+	_, err = Parse(&errorReader{})
+	c.Assert(err, ErrorMatches, "Can't read list: .*")
+}
+
+func (s *AllowlistSuite) TestDiff(c *C) {
+	prev, _ := Parse(strings.NewReader("91.121.222.175/32 # lan\n1.1.1.1/32 # old\n"))
+	cur := FromNodes(updown.Nodes{
+		"lan": {IP: "91.121.222.175"},
+		"fra": {IP: "104.238.159.87"},
+	})
+
+	changes := Diff(prev, cur)
+
+	c.Assert(changes.IsEmpty(), Equals, false)
+	c.Assert(changes.Added, HasLen, 1)
+	c.Assert(changes.Removed, HasLen, 1)
+	c.Assert(changes.String(), Equals, "- 1.1.1.1/32 # old\n+ 104.238.159.87/32 # fra\n")
+
+	c.Assert(Diff(cur, cur).IsEmpty(), Equals, true)
+	c.Assert(Diff(cur, cur).String(), Equals, "")
+}
+
+func (s *AllowlistSuite) TestFormats(c *C) {
+	l := FromNodes(updown.Nodes{
+		"lan": {IP: "91.121.222.175", IPv6: "2001:41d0:2:85af::1"},
+		"mia": {IP: "104.238.136.194"},
+	})
+
+	c.Assert(l.Write(nil, FORMAT_CIDR, nil), Equals, ErrNilWriter)
+	c.Assert(l.Write(&bytes.Buffer{}, "xml", nil), Equals, ErrUnknownFormat)
+	c.Assert(Entries{}.Write(&bytes.Buffer{}, FORMAT_K8S, nil), Equals, ErrEmptyList)
+	c.Assert(Entries{}.Write(&bytes.Buffer{}, FORMAT_AWS, nil), Equals, ErrEmptyList)
+	c.Assert(l.IPv6().Write(&bytes.Buffer{}, FORMAT_K8S, nil), IsNil)
+	c.Assert(Entries{}.Write(&bytes.Buffer{}, FORMAT_CIDR, nil), IsNil)
+
+	c.Assert(render(l, FORMAT_CIDR, nil), Equals, "91.121.222.175/32 # lan\n104.238.136.194/32 # mia\n2001:41d0:2:85af::1/128 # lan\n")
+
+	c.Assert(render(l, FORMAT_IPTABLES, nil), Equals, `iptables -N updown
+iptables -A updown -s 91.121.222.175/32 -j ACCEPT -m comment --comment "updown lan"
+iptables -A updown -s 104.238.136.194/32 -j ACCEPT -m comment --comment "updown mia"
+`)
+
+	c.Assert(render(mustFromIPs("1.1.1.1"), FORMAT_IPTABLES, &Options{Name: "UPDOWN", Ports: []int{443}}), Equals, `iptables -N UPDOWN
+iptables -A UPDOWN -s 1.1.1.1/32 -p tcp --dport 443 -j ACCEPT
+`)
+
+	c.Assert(render(l, FORMAT_IP6TABLES, &Options{Ports: []int{80, 443}}), Equals, `ip6tables -N updown
+ip6tables -A updown -s 2001:41d0:2:85af::1/128 -p tcp -m multiport --dports 80,443 -j ACCEPT -m comment --comment "updown lan"
+`)
+
+	c.Assert(render(l, FORMAT_NFTABLES, nil), Equals, `set updown_ipv4 {
+  type ipv4_addr
+  elements = {
+    91.121.222.175,
+    104.238.136.194
+  }
+}
+
+set updown_ipv6 {
+  type ipv6_addr
+  elements = {
+    2001:41d0:2:85af::1
+  }
+}
+`)
+
+	c.Assert(render(l.IPv4(), FORMAT_NFTABLES, nil), Equals, `set updown_ipv4 {
+  type ipv4_addr
+  elements = {
+    91.121.222.175,
+    104.238.136.194
+  }
+}
+
+set updown_ipv6 {
+  type ipv6_addr
+}
+`)
+
+	c.Assert(render(append(l, &Entry{Addr: netip.MustParseAddr("1.1.1.1")}), FORMAT_NGINX, nil), Equals, `allow 91.121.222.175; # lan
+allow 104.238.136.194; # mia
+allow 2001:41d0:2:85af::1; # lan
+allow 1.1.1.1;
+`)
+
+	c.Assert(render(l, FORMAT_K8S, nil), Equals, `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: updown
+spec:
+  podSelector: {}
+  policyTypes:
+    - Ingress
+  ingress:
+    - from:
+        - ipBlock:
+            cidr: 91.121.222.175/32
+        - ipBlock:
+            cidr: 104.238.136.194/32
+        - ipBlock:
+            cidr: 2001:41d0:2:85af::1/128
+`)
+
+	c.Assert(render(l.IPv4()[:1], FORMAT_K8S, &Options{
+		Name: "allow-updown", Namespace: "web", Ports: []int{443},
+		PodSelector: map[string]string{"app": "site", "public": "true"},
+	}), Equals, `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: allow-updown
+  namespace: web
+spec:
+  podSelector:
+    matchLabels:
+      app: site
+      public: "true"
+  policyTypes:
+    - Ingress
+  ingress:
+    - from:
+        - ipBlock:
+            cidr: 91.121.222.175/32
+      ports:
+        - protocol: TCP
+          port: 443
+`)
+
+	var perms []map[string]any
+
+	c.Assert(json.Unmarshal([]byte(render(l, FORMAT_AWS, nil)), &perms), IsNil)
+	c.Assert(perms, HasLen, 1)
+	c.Assert(perms[0]["IpProtocol"], Equals, "-1")
+	c.Assert(perms[0]["IpRanges"], HasLen, 2)
+	c.Assert(perms[0]["Ipv6Ranges"], HasLen, 1)
+	c.Assert(perms[0]["FromPort"], IsNil)
+
+	c.Assert(json.Unmarshal([]byte(render(l, FORMAT_AWS, &Options{Ports: []int{80, 443}})), &perms), IsNil)
+	c.Assert(perms, HasLen, 2)
+	c.Assert(perms[0]["IpProtocol"], Equals, "tcp")
+	c.Assert(perms[0]["FromPort"], Equals, 80.0)
+	c.Assert(perms[1]["ToPort"], Equals, 443.0)
+	c.Assert(perms[1]["IpRanges"].([]any)[0].(map[string]any)["Description"], Equals, "updown lan")
+
+	c.Assert(l.Write(&errorWriter{}, FORMAT_CIDR, nil), NotNil)
+}
+
+func (s *AllowlistSuite) TestHelpers(c *C) {
+	c.Assert(quoteYAML("updown"), Equals, "updown")
+	c.Assert(quoteYAML(""), Equals, `""`)
+	c.Assert(quoteYAML("123"), Equals, `"123"`)
+	c.Assert(quoteYAML("Yes"), Equals, `"Yes"`)
+	c.Assert(quoteYAML("a: b"), Equals, `"a: b"`)
+
+	var changes *Changes
+	c.Assert(changes.IsEmpty(), Equals, true)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+type errorReader struct{}
+type errorWriter struct{}
+
+func (r *errorReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read error")
+}
+
+func (w *errorWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func render(l Entries, format Format, options *Options) string {
+	var buf bytes.Buffer
+	l.Write(&buf, format, options)
+	return buf.String()
+}
+
+func mustFromIPs(ips ...string) Entries {
+	l, _ := FromIPs(ips)
+	return l
+}
@@ -0,0 +1,278 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_CONCURRENCY is default maximum number of concurrent requests made by
+// batch methods
+const DEFAULT_CONCURRENCY = 8
+
+const (
+	// BATCH_MAX_RETRIES is maximum number of retries of request rejected by
+	// API with 429 status code in batch methods
+	BATCH_MAX_RETRIES = 3
+
+	// BATCH_RETRY_PAUSE is base pause before retry if API didn't provide
+	// Retry-After header (pause is doubled after every retry)
+	BATCH_RETRY_PAUSE = time.Second
+
+	// BATCH_MAX_RETRY_PAUSE is maximum pause before retry. Requests are not
+	// retried if API asks to wait longer.
+	BATCH_MAX_RETRY_PAUSE = time.Minute
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rateLimiter is goroutine-safe requests rate limiter
+type rateLimiter struct {
+	mu    sync.Mutex
+	delay time.Duration
+	next  time.Time
+}
+
+// backoff is pause shared by all goroutines of batch
+type backoff struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetLimit sets maximum number of API requests per second (0 disables limit).
+// Limit is shared between all goroutines using the client, including batch
+// methods. Responses served from cache are not limited.
+//
+// By default, requests are not limited. Batch methods retry requests rejected
+// with 429 status code (see BATCH_MAX_RETRIES), but other methods return an
+// error, so it's recommended to set the limit if client is used for making a lot
+// of concurrent requests.
+func (c *Client) SetLimit(rps float64) {
+	if c == nil {
+		return
+	}
+
+	c.limiter.Store(newRateLimiter(rps))
+}
+
+// SetConcurrency sets maximum number of concurrent requests made by batch
+// methods (DEFAULT_CONCURRENCY is used if n is 0 or less)
+func (c *Client) SetConcurrency(n int) {
+	if c == nil {
+		return
+	}
+
+	c.concurrency.Store(int64(n))
+}
+
+// GetChecksDetailed fetches checks with given tokens with metrics using
+// concurrent requests. Requests rejected by API with 429 status code are
+// retried. Returns map token → check and map token → error for failed requests.
+//
+// https://updown.io/api#GET-/api/checks/:token
+func (c *Client) GetChecksDetailed(tokens []string) (map[string]*Check, map[string]error) {
+	return c.GetChecksDetailedContext(context.Background(), tokens)
+}
+
+// GetChecksDetailedContext fetches checks with given tokens with metrics using
+// concurrent requests and given context
+//
+// https://updown.io/api#GET-/api/checks/:token
+func (c *Client) GetChecksDetailedContext(ctx context.Context, tokens []string) (map[string]*Check, map[string]error) {
+	return batch(c, tokens, func(token string) (*Check, error) {
+		return c.GetCheckContext(ctx, token, true)
+	})
+}
+
+// GetMetricsMany fetches metrics for checks with given tokens using concurrent
+// requests. Requests rejected by API with 429 status code are retried. Returns
+// map token → metrics and map token → error for failed requests.
+//
+// https://updown.io/api#GET-/api/checks/:token/metrics
+func (c *Client) GetMetricsMany(tokens []string, options MetricsOptions) (map[string]*Metrics, map[string]error) {
+	return c.GetMetricsManyContext(context.Background(), tokens, options)
+}
+
+// GetMetricsManyContext fetches metrics for checks with given tokens using
+// concurrent requests and given context
+//
+// https://updown.io/api#GET-/api/checks/:token/metrics
+func (c *Client) GetMetricsManyContext(ctx context.Context, tokens []string, options MetricsOptions) (map[string]*Metrics, map[string]error) {
+	return batch(c, tokens, func(token string) (*Metrics, error) {
+		return c.GetMetricsContext(ctx, token, options)
+	})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Wait blocks until next request slot becomes available
+func (l *rateLimiter) Wait() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+
+	now := time.Now()
+
+	if l.next.Before(now) {
+		l.next = now
+	}
+
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.delay)
+
+	l.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// Wait blocks until pause is over
+func (b *backoff) Wait() {
+	b.mu.Lock()
+	wait := time.Until(b.until)
+	b.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// Delay extends pause by given duration
+func (b *backoff) Delay(d time.Duration) {
+	b.mu.Lock()
+
+	if until := time.Now().Add(d); until.After(b.until) {
+		b.until = until
+	}
+
+	b.mu.Unlock()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newRateLimiter creates new rate limiter. Returns nil if rps is 0 or less.
+func newRateLimiter(rps float64) *rateLimiter {
+	if rps <= 0 {
+		return nil
+	}
+
+	return &rateLimiter{delay: time.Duration(float64(time.Second) / rps)}
+}
+
+// batch calls given function for every unique token with bounded concurrency
+func batch[T any](c *Client, tokens []string, fn func(token string) (T, error)) (map[string]T, map[string]error) {
+	results := make(map[string]T, len(tokens))
+	errs := map[string]error{}
+
+	if c == nil || c.engine == nil {
+		for _, token := range tokens {
+			errs[token] = ErrNilClient
+		}
+
+		return results, errs
+	}
+
+	concurrency := int(c.concurrency.Load())
+
+	if concurrency <= 0 {
+		concurrency = DEFAULT_CONCURRENCY
+	}
+
+	// Engine is initialized lazily on the first request, so we have to
+	// initialize it before making concurrent requests
+	c.engine.Init()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var bo backoff
+
+	sem := make(chan struct{}, concurrency)
+	seen := make(map[string]bool, len(tokens))
+
+	for _, token := range tokens {
+		if seen[token] {
+			continue
+		}
+
+		seen[token] = true
+
+		if token == "" {
+			mu.Lock()
+			errs[token] = ErrEmptyToken
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			result, err := retry(&bo, token, fn)
+
+			mu.Lock()
+
+			if err != nil {
+				errs[token] = err
+			} else {
+				results[token] = result
+			}
+
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	return results, errs
+}
+
+// retry calls given function and retries it if API rejected request with 429
+// status code
+func retry[T any](bo *backoff, token string, fn func(token string) (T, error)) (T, error) {
+	for attempt := 0; ; attempt++ {
+		bo.Wait()
+
+		result, err := fn(token)
+
+		var se *statusError
+
+		if attempt == BATCH_MAX_RETRIES || !errors.As(err, &se) ||
+			se.code != http.StatusTooManyRequests {
+			return result, err
+		}
+
+		pause := se.retryAfter
+
+		if pause < 0 {
+			pause = BATCH_RETRY_PAUSE << attempt
+		}
+
+		if pause > BATCH_MAX_RETRY_PAUSE {
+			return result, err
+		}
+
+		// Pause is shared, so other goroutines also stop sending requests
+		bo.Delay(pause)
+	}
+}
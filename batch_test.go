@@ -0,0 +1,185 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestGetChecksDetailed(c *C) {
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+
+	api.SetConcurrency(2)
+
+	checks, errs := api.GetChecksDetailed([]string{"ngg8", "abcd", "", "ngg8"})
+
+	c.Assert(checks, HasLen, 1)
+	c.Assert(checks["ngg8"].URL, Equals, "https://updown.io")
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs["abcd"], NotNil)
+	c.Assert(errs[""], Equals, ErrEmptyToken)
+	c.Assert(api.Calls(), Equals, uint(2))
+}
+
+func (s *UpdownSuite) TestGetMetricsMany(c *C) {
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+
+	var active, maxActive atomic.Int32
+
+	api.SetConcurrency(3)
+	api.Use(func(next RoundTrip) RoundTrip {
+		return func(r *APIRequest) (*APIResponse, error) {
+			n := active.Add(1)
+
+			for {
+				m := maxActive.Load()
+
+				if n <= m || maxActive.CompareAndSwap(m, n) {
+					break
+				}
+			}
+
+			time.Sleep(20 * time.Millisecond)
+			defer active.Add(-1)
+
+			return next(r)
+		}
+	})
+
+	tokens := []string{"ngg8", "t001", "t002", "t003", "t004", "t005", "t006"}
+	metrics, errs := api.GetMetricsMany(tokens, MetricsOptions{
+		From: time.Now().Add(-time.Hour), To: time.Now(),
+	})
+
+	c.Assert(metrics, HasLen, 1)
+	c.Assert(metrics["ngg8"].Uptime, Equals, 99.999)
+	c.Assert(errs, HasLen, 6)
+	c.Assert(maxActive.Load() > 1, Equals, true)
+	c.Assert(maxActive.Load() <= 3, Equals, true)
+
+	_, errs = api.GetMetricsMany([]string{"ngg8"}, MetricsOptions{
+		From: time.Now(), To: time.Now().Add(-time.Hour),
+	})
+
+	c.Assert(errs["ngg8"], Equals, ErrInvalidMetricsRange)
+}
+
+func (s *UpdownSuite) TestBatchLimit(c *C) {
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+
+	api.SetLimit(20)
+	api.SetConcurrency(10)
+
+	start := time.Now()
+	_, errs := api.GetMetricsMany([]string{"t001", "t002", "t003", "t004", "t005"}, MetricsOptions{})
+
+	c.Assert(errs, HasLen, 5)
+	c.Assert(time.Since(start) >= 200*time.Millisecond, Equals, true)
+
+	api.SetLimit(0)
+	c.Assert(api.limiter.Load(), IsNil)
+}
+
+func (s *UpdownSuite) TestBatchRetry(c *C) {
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+
+	var calls sync.Map
+
+	api.Use(func(next RoundTrip) RoundTrip {
+		return func(r *APIRequest) (*APIResponse, error) {
+			token := getToken(r.Endpoint)
+			n, _ := calls.LoadOrStore(token, &atomic.Int32{})
+
+			retryAfter := "0"
+
+			switch {
+			case token == "t001":
+				retryAfter = "3600"
+			case token == "ngg8" && n.(*atomic.Int32).Load() >= 2:
+				return next(r)
+			}
+
+			n.(*atomic.Int32).Add(1)
+
+			return &APIResponse{
+				StatusCode: 429,
+				Header:     http.Header{"Retry-After": []string{retryAfter}},
+			}, nil
+		}
+	})
+
+	checks, errs := api.GetChecksDetailed([]string{"ngg8", "t001", "t002"})
+
+	c.Assert(checks, HasLen, 1)
+	c.Assert(checks["ngg8"], NotNil)
+	c.Assert(errs, HasLen, 2)
+	c.Assert(errs["t001"], ErrorMatches, "API returned non-ok status code 429")
+	c.Assert(errs["t002"], ErrorMatches, "API returned non-ok status code 429")
+
+	n, _ := calls.Load("t001")
+	c.Assert(n.(*atomic.Int32).Load(), Equals, int32(1))
+	n, _ = calls.Load("t002")
+	c.Assert(n.(*atomic.Int32).Load(), Equals, int32(BATCH_MAX_RETRIES+1))
+}
+
+func (s *UpdownSuite) TestRetryAfter(c *C) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	h := http.Header{}
+
+	c.Assert(getRetryAfter(h, now), Equals, time.Duration(-1))
+
+	h.Set("Retry-After", "120")
+	c.Assert(getRetryAfter(h, now), Equals, 2*time.Minute)
+
+	h.Set("Retry-After", now.Add(30*time.Second).Format(http.TimeFormat))
+	c.Assert(getRetryAfter(h, now), Equals, 30*time.Second)
+
+	h.Set("Retry-After", now.Add(-time.Hour).Format(http.TimeFormat))
+	c.Assert(getRetryAfter(h, now), Equals, time.Duration(0))
+
+	h.Set("Retry-After", "soon")
+	c.Assert(getRetryAfter(h, now), Equals, time.Duration(-1))
+}
+
+func (s *UpdownSuite) TestBatchErrors(c *C) {
+	var api *Client
+
+	api.SetLimit(10)
+	api.SetConcurrency(10)
+
+	checks, errs := api.GetChecksDetailed([]string{"ngg8"})
+
+	c.Assert(checks, HasLen, 0)
+	c.Assert(errs["ngg8"], Equals, ErrNilClient)
+
+	metrics, errs := api.GetMetricsMany([]string{"ngg8"}, MetricsOptions{})
+
+	c.Assert(metrics, HasLen, 0)
+	c.Assert(errs["ngg8"], Equals, ErrNilClient)
+
+	var l *rateLimiter
+
+	l.Wait()
+
+	c.Assert(newRateLimiter(-1), IsNil)
+}
@@ -0,0 +1,326 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/req"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_CACHE_TTL is default TTL for routes without configured TTL
+const DEFAULT_CACHE_TTL = time.Minute
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CacheConfig contains response cache configuration
+type CacheConfig struct {
+	// TTL contains TTL for API routes (e.g. "/nodes" or "/checks/:token").
+	// Values override default TTLs, zero or negative value disables caching
+	// for route.
+	TTL map[string]time.Duration
+
+	// DefaultTTL is TTL for routes which are not present in TTL map and
+	// default TTLs
+	DefaultTTL time.Duration
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DefaultCacheTTL contains default TTLs for API routes
+var DefaultCacheTTL = map[string]time.Duration{
+	"/checks":                  30 * time.Second,
+	"/checks/:token":           30 * time.Second,
+	"/checks/:token/downtimes": time.Minute,
+	"/checks/:token/metrics":   5 * time.Minute,
+	"/nodes":                   24 * time.Hour,
+	"/nodes/ips":               24 * time.Hour,
+	"/nodes/ipv4":              24 * time.Hour,
+	"/nodes/ipv6":              24 * time.Hour,
+	"/recipients":              5 * time.Minute,
+	"/status-pages":            5 * time.Minute,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// responseCache is cache for API responses
+type responseCache struct {
+	config CacheConfig
+
+	mu    sync.Mutex
+	items map[string]*cacheItem
+	calls map[string]*cacheCall
+}
+
+// cacheItem is cached API response
+type cacheItem struct {
+	endpoint string
+	resp     *APIResponse
+	etag     string
+	expires  time.Time
+}
+
+// cacheCall is in-flight request shared by concurrent callers
+type cacheCall struct {
+	done chan struct{}
+	resp *APIResponse
+	err  error
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// EnableCache enables caching of API responses. Cached responses are served
+// until TTL expires, after that response is revalidated using ETag (if API
+// provided it). Concurrent identical requests are collapsed into one API
+// request. Middlewares are called for every request, including requests served
+// from cache.
+func (c *Client) EnableCache(config CacheConfig) {
+	if c == nil {
+		return
+	}
+
+	ttl := make(map[string]time.Duration, len(DefaultCacheTTL)+len(config.TTL))
+
+	for route, d := range DefaultCacheTTL {
+		ttl[route] = d
+	}
+
+	for route, d := range config.TTL {
+		ttl[route] = d
+	}
+
+	if config.DefaultTTL == 0 {
+		config.DefaultTTL = DEFAULT_CACHE_TTL
+	}
+
+	config.TTL = ttl
+
+	c.cache.Store(&responseCache{
+		config: config,
+		items:  map[string]*cacheItem{},
+		calls:  map[string]*cacheCall{},
+	})
+}
+
+// DisableCache disables caching of API responses and removes all cached data
+func (c *Client) DisableCache() {
+	if c == nil {
+		return
+	}
+
+	c.cache.Store(nil)
+}
+
+// InvalidateCache removes cached responses for given endpoints (e.g. "/nodes"
+// or "/checks/ngg8") including all nested endpoints. If no endpoints are given,
+// all cached data will be removed.
+func (c *Client) InvalidateCache(endpoints ...string) {
+	if c == nil {
+		return
+	}
+
+	if cache := c.cache.Load(); cache != nil {
+		cache.invalidate(endpoints...)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// wrap wraps round trip with cache
+func (rc *responseCache) wrap(next RoundTrip) RoundTrip {
+	return func(r *APIRequest) (*APIResponse, error) {
+		if r.Method != req.GET && r.Method != "" {
+			resp, err := next(r)
+
+			// Any successful modification makes cached data outdated
+			if err == nil && resp != nil && resp.StatusCode < 400 {
+				rc.invalidate(getResource(r.Endpoint))
+			}
+
+			return resp, err
+		}
+
+		ttl := rc.getTTL(r.Endpoint)
+
+		if ttl <= 0 {
+			return next(r)
+		}
+
+		return rc.get(getCacheKey(r), r, ttl, next)
+	}
+}
+
+// get returns cached response or sends request to API
+func (rc *responseCache) get(key string, r *APIRequest, ttl time.Duration, next RoundTrip) (*APIResponse, error) {
+	rc.mu.Lock()
+
+	item := rc.items[key]
+
+	if item != nil && time.Now().Before(item.expires) {
+		rc.mu.Unlock()
+		return item.resp.clone(), nil
+	}
+
+	if call := rc.calls[key]; call != nil {
+		rc.mu.Unlock()
+		<-call.done
+		return call.resp.clone(), call.err
+	}
+
+	call := &cacheCall{done: make(chan struct{})}
+	rc.calls[key] = call
+
+	rc.mu.Unlock()
+
+	if item != nil && item.etag != "" {
+		r.Headers["If-None-Match"] = item.etag
+	}
+
+	resp, err := next(r)
+
+	rc.mu.Lock()
+
+	switch {
+	case err != nil || resp == nil:
+		// do nothing
+
+	case resp.StatusCode == 304 && item != nil:
+		resp = item.resp
+		rc.store(key, call, &cacheItem{
+			endpoint: item.endpoint,
+			resp:     item.resp,
+			etag:     item.etag,
+			expires:  time.Now().Add(ttl),
+		})
+
+	case resp.StatusCode == req.STATUS_OK:
+		rc.store(key, call, &cacheItem{
+			endpoint: r.Endpoint,
+			resp:     resp.clone(),
+			etag:     resp.Header.Get("ETag"),
+			expires:  time.Now().Add(ttl),
+		})
+	}
+
+	if rc.calls[key] == call {
+		delete(rc.calls, key)
+	}
+
+	call.resp, call.err = resp, err
+	close(call.done)
+
+	rc.mu.Unlock()
+
+	return resp.clone(), err
+}
+
+// invalidate removes cached responses for given endpoints
+func (rc *responseCache) invalidate(endpoints ...string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if len(endpoints) == 0 {
+		rc.items = map[string]*cacheItem{}
+		rc.calls = map[string]*cacheCall{}
+		return
+	}
+
+	for key, item := range rc.items {
+		if hasEndpointPrefix(item.endpoint, endpoints) {
+			delete(rc.items, key)
+		}
+	}
+
+	// Requests which are in progress can return outdated data, so we detach
+	// them from cache
+	for key := range rc.calls {
+		endpoint, _, _ := strings.Cut(key, "?")
+
+		if hasEndpointPrefix(endpoint, endpoints) {
+			delete(rc.calls, key)
+		}
+	}
+}
+
+// store saves item to cache if request wasn't detached by invalidation
+func (rc *responseCache) store(key string, call *cacheCall, item *cacheItem) {
+	if rc.calls[key] == call {
+		rc.items[key] = item
+	}
+}
+
+// getTTL returns TTL for given endpoint
+func (rc *responseCache) getTTL(endpoint string) time.Duration {
+	ttl, ok := rc.config.TTL[getRoute(endpoint)]
+
+	if !ok {
+		return rc.config.DefaultTTL
+	}
+
+	return ttl
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// clone creates copy of response
+func (r *APIResponse) clone() *APIResponse {
+	if r == nil {
+		return nil
+	}
+
+	return &APIResponse{
+		StatusCode: r.StatusCode,
+		Header:     r.Header.Clone(),
+		Body:       append([]byte(nil), r.Body...),
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getCacheKey returns cache key for given request
+func getCacheKey(r *APIRequest) string {
+	if len(r.Query) == 0 {
+		return r.Endpoint
+	}
+
+	values := url.Values{}
+
+	for k, v := range r.Query {
+		values.Set(k, fmt.Sprint(v))
+	}
+
+	// url.Values encodes values sorted by key, so keys are stable
+	return r.Endpoint + "?" + values.Encode()
+}
+
+// getResource returns top-level resource for given endpoint (e.g. "/checks" for
+// "/checks/ngg8/metrics")
+func getResource(endpoint string) string {
+	resource, _, _ := strings.Cut(strings.TrimPrefix(endpoint, "/"), "/")
+	return "/" + resource
+}
+
+// hasEndpointPrefix returns true if endpoint is equal or nested to one of
+// given endpoints
+func hasEndpointPrefix(endpoint string, endpoints []string) bool {
+	for _, e := range endpoints {
+		e = strings.TrimSuffix(e, "/")
+
+		if endpoint == e || strings.HasPrefix(endpoint, e+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,183 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/req"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestCache(c *C) {
+	var nc *Client
+
+	nc.EnableCache(CacheConfig{})
+	nc.DisableCache()
+	nc.InvalidateCache()
+
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+
+	api.InvalidateCache()
+	api.EnableCache(CacheConfig{TTL: map[string]time.Duration{"/checks/:token": 0}})
+
+	c.Assert(api.cache.Load().config.DefaultTTL, Equals, DEFAULT_CACHE_TTL)
+	c.Assert(api.cache.Load().getTTL("/nodes"), Equals, 24*time.Hour)
+	c.Assert(api.cache.Load().getTTL("/checks/ngg8"), Equals, time.Duration(0))
+	c.Assert(api.cache.Load().getTTL("/unknown"), Equals, DEFAULT_CACHE_TTL)
+
+	for range 3 {
+		nodes, err := api.GetNodes()
+		c.Assert(err, IsNil)
+		c.Assert(nodes, HasLen, 10)
+	}
+
+	c.Assert(api.Calls(), Equals, uint(1))
+
+	api.GetCheck("ngg8", false)
+	api.GetCheck("ngg8", false)
+
+	c.Assert(api.Calls(), Equals, uint(3))
+
+	api.GetMetrics("ngg8", MetricsOptions{GroupBy: GROUP_BY_HOST})
+	api.GetMetrics("ngg8", MetricsOptions{})
+
+	c.Assert(api.Calls(), Equals, uint(4))
+
+	api.InvalidateCache("/checks/ngg8")
+	api.GetMetrics("ngg8", MetricsOptions{})
+	api.GetNodes()
+
+	c.Assert(api.Calls(), Equals, uint(5))
+
+	api.InvalidateCache()
+	api.GetNodes()
+
+	c.Assert(api.Calls(), Equals, uint(6))
+
+	api.DisableCache()
+	api.GetNodes()
+
+	c.Assert(api.Calls(), Equals, uint(7))
+}
+
+func (s *UpdownSuite) TestCacheToggle(c *C) {
+	api, _ := NewClient("test1234")
+	api.GetNodes()
+
+	var wg sync.WaitGroup
+
+	for i := range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 10 {
+				if i%2 == 0 {
+					api.EnableCache(CacheConfig{})
+					api.InvalidateCache("/nodes")
+					api.DisableCache()
+				} else {
+					_, err := api.GetNodes()
+					c.Check(err, IsNil)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (s *UpdownSuite) TestCacheETag(c *C) {
+	var etags []string
+
+	api, _ := NewClient("test1234")
+	api.EnableCache(CacheConfig{TTL: map[string]time.Duration{"/status-pages": time.Millisecond}})
+	api.Use(func(next RoundTrip) RoundTrip {
+		return func(r *APIRequest) (*APIResponse, error) {
+			resp, err := next(r)
+			etags = append(etags, r.Headers["If-None-Match"])
+			return resp, err
+		}
+	})
+
+	pages, err := api.GetStatusPages()
+
+	c.Assert(err, IsNil)
+	c.Assert(pages, HasLen, 1)
+
+	time.Sleep(5 * time.Millisecond)
+
+	pages, err = api.GetStatusPages()
+
+	c.Assert(err, IsNil)
+	c.Assert(pages, HasLen, 1)
+	c.Assert(pages[0].Token, Equals, "3ji4k")
+	c.Assert(api.Calls(), Equals, uint(2))
+	c.Assert(etags, DeepEquals, []string{"", `W/"3ji4k"`})
+}
+
+func (s *UpdownSuite) TestCacheSingleflight(c *C) {
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+
+	api, _ := NewClient("test1234")
+	api.EnableCache(CacheConfig{})
+
+	rt := api.cache.Load().wrap(func(r *APIRequest) (*APIResponse, error) {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		return &APIResponse{StatusCode: 200, Body: []byte(`[]`)}, nil
+	})
+
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := rt(&APIRequest{Method: req.GET, Endpoint: "/checks", Headers: req.Headers{}})
+			c.Check(err, IsNil)
+			c.Check(string(resp.Body), Equals, "[]")
+		}()
+	}
+
+	wg.Wait()
+
+	c.Assert(calls.Load(), Equals, int32(1))
+
+	// Modification invalidates all related data
+	rt(&APIRequest{Method: req.DELETE, Endpoint: "/checks/ngg8"})
+	rt(&APIRequest{Method: req.GET, Endpoint: "/checks", Headers: req.Headers{}})
+
+	c.Assert(calls.Load(), Equals, int32(3))
+}
+
+func (s *UpdownSuite) TestCacheHelpers(c *C) {
+	c.Assert(getCacheKey(&APIRequest{Endpoint: "/checks"}), Equals, "/checks")
+	c.Assert(
+		getCacheKey(&APIRequest{Endpoint: "/checks/ngg8/metrics", Query: req.Query{"to": "2", "from": "1"}}),
+		Equals, "/checks/ngg8/metrics?from=1&to=2",
+	)
+
+	c.Assert(getResource("/checks/ngg8/metrics"), Equals, "/checks")
+	c.Assert(getResource("/nodes"), Equals, "/nodes")
+
+	c.Assert(hasEndpointPrefix("/checks/ngg8", []string{"/checks/"}), Equals, true)
+	c.Assert(hasEndpointPrefix("/checks", []string{"/checks"}), Equals, true)
+	c.Assert(hasEndpointPrefix("/checksum", []string{"/checks"}), Equals, false)
+
+	var r *APIResponse
+	c.Assert(r.clone(), IsNil)
+}
@@ -0,0 +1,511 @@
+package main
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/terminal"
+
+	"github.com/essentialkaos/updown"
+	"github.com/essentialkaos/updown/pulse"
+)
+
+// exitError is error with custom exit code
+type exitError struct {
+	code int
+	err  error
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// dateFormats is a list of supported date formats for metrics range
+var dateFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cmdChecks runs "checks" command
+func cmdChecks(client *updown.Client, args options.Arguments) error {
+	subCmd := CMD_LIST
+
+	if len(args) != 0 {
+		subCmd = args.Get(0).ToLower().String()
+	}
+
+	switch subCmd {
+	case CMD_LIST:
+		return listChecks(client)
+	case CMD_CREATE:
+		return createCheck(client)
+	case CMD_SHOW, CMD_UPDATE, CMD_DELETE:
+		// continue
+	default:
+		return fmt.Errorf("Unknown checks command %q", subCmd)
+	}
+
+	if !args.Has(1) {
+		return fmt.Errorf("You must define check token or alias")
+	}
+
+	token, err := resolveToken(client, args.Get(1).String())
+
+	if err != nil {
+		return err
+	}
+
+	switch subCmd {
+	case CMD_SHOW:
+		return showCheck(client, token)
+	case CMD_UPDATE:
+		return updateCheck(client, token)
+	}
+
+	return deleteCheck(client, token)
+}
+
+// cmdDowntimes runs "downtimes" command
+func cmdDowntimes(client *updown.Client, args options.Arguments) error {
+	if !args.Has(0) {
+		return fmt.Errorf("You must define check token or alias")
+	}
+
+	token, err := resolveToken(client, args.Get(0).String())
+
+	if err != nil {
+		return err
+	}
+
+	downtimes, err := client.GetDowntimes(token, options.GetB(OPT_DETAILED))
+
+	if err != nil {
+		return fmt.Errorf("Can't get downtimes: %w", err)
+	}
+
+	return render(downtimes, downtimesRecords(downtimes))
+}
+
+// cmdMetrics runs "metrics" command
+func cmdMetrics(client *updown.Client, args options.Arguments) error {
+	if !args.Has(0) {
+		return fmt.Errorf("You must define check token or alias")
+	}
+
+	metricsOptions, err := getMetricsOptions(
+		options.GetS(OPT_FROM), options.GetS(OPT_TO),
+		options.GetS(OPT_RANGE), options.GetS(OPT_GROUP),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	token, err := resolveToken(client, args.Get(0).String())
+
+	if err != nil {
+		return err
+	}
+
+	switch metricsOptions.GroupBy {
+	case updown.GROUP_BY_TIME:
+		series, err := client.GetMetricsByTime(token, metricsOptions)
+
+		if err != nil {
+			return fmt.Errorf("Can't get metrics: %w", err)
+		}
+
+		data := map[string]*updown.Metrics{}
+
+		for _, p := range series {
+			data[p.Time.UTC().Format(time.RFC3339)] = p.Metrics
+		}
+
+		return render(series, metricsRecords("time", data))
+
+	case updown.GROUP_BY_HOST:
+		hosts, err := client.GetMetricsByHost(token, metricsOptions)
+
+		if err != nil {
+			return fmt.Errorf("Can't get metrics: %w", err)
+		}
+
+		return render(hosts, metricsRecords("node", hosts))
+	}
+
+	metrics, err := client.GetMetrics(token, metricsOptions)
+
+	if err != nil {
+		return fmt.Errorf("Can't get metrics: %w", err)
+	}
+
+	return render(metrics, metricsRecords("check", map[string]*updown.Metrics{token: metrics}))
+}
+
+// cmdNodes runs "nodes" command
+func cmdNodes(client *updown.Client) error {
+	nodes, err := client.GetNodes()
+
+	if err != nil {
+		return fmt.Errorf("Can't get nodes: %w", err)
+	}
+
+	return render(nodes, nodesRecords(nodes))
+}
+
+// cmdRecipients runs "recipients" command
+func cmdRecipients(client *updown.Client) error {
+	recipients, err := client.GetRecipients()
+
+	if err != nil {
+		return fmt.Errorf("Can't get recipients: %w", err)
+	}
+
+	return render(recipients, recipientsRecords(recipients))
+}
+
+// cmdStatusPages runs "status-pages" command
+func cmdStatusPages(client *updown.Client) error {
+	pages, err := client.GetStatusPages()
+
+	if err != nil {
+		return fmt.Errorf("Can't get status pages: %w", err)
+	}
+
+	return render(pages, statusPagesRecords(pages))
+}
+
+// cmdPulse runs "pulse" command
+func cmdPulse(args options.Arguments) error {
+	if args.Get(0).Is(CMD_EXEC) {
+		return cmdPulseExec(args[1:])
+	}
+
+	if !args.Has(0) {
+		return fmt.Errorf("You must define pulse URL")
+	}
+
+	uuid, err := updown.SendPulse(args.Get(0).String(), options.GetS(OPT_PAYLOAD))
+
+	if err != nil {
+		return err
+	}
+
+	fmtc.Printfn("{g}Pulse successfully sent {s-}(%s){!}", uuid)
+
+	return nil
+}
+
+// cmdPulseExec runs "pulse exec" command
+func cmdPulseExec(args options.Arguments) error {
+	url, cmd, err := parseExecArgs(args.Strings(), os.Getenv(ENV_PULSE_URL))
+
+	if err != nil {
+		return err
+	}
+
+	runner := pulse.NewRunner(url)
+	runner.ReportFailures = options.GetB(OPT_REPORT)
+	runner.Stdout, runner.Stderr = os.Stdout, os.Stderr
+
+	if options.Has(OPT_TIMEOUT) {
+		runner.Timeout, err = time.ParseDuration(options.GetS(OPT_TIMEOUT))
+
+		if err != nil {
+			return fmt.Errorf("Can't parse timeout: %w", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report := runner.Run(ctx, cmd[0], cmd[1:]...)
+
+	if report.PulseError != nil {
+		terminal.Warn("Can't send pulse: %v", report.PulseError)
+	}
+
+	if !report.IsSuccess() {
+		code := report.ExitCode
+
+		if code <= 0 {
+			code = 1
+		}
+
+		return exitError{code, fmt.Errorf("Command failed: %w", report.Error)}
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// listChecks prints all checks
+func listChecks(client *updown.Client) error {
+	checks, err := client.GetChecks()
+
+	if err != nil {
+		return fmt.Errorf("Can't get checks: %w", err)
+	}
+
+	return render(checks, checksRecords(checks))
+}
+
+// showCheck prints info about check
+func showCheck(client *updown.Client, token string) error {
+	check, err := client.GetCheck(token, options.GetB(OPT_METRICS))
+
+	if err != nil {
+		return fmt.Errorf("Can't get check: %w", err)
+	}
+
+	return render(check, checkRecords(check))
+}
+
+// createCheck creates new check
+func createCheck(client *updown.Client) error {
+	checkOptions, err := getCheckOptions()
+
+	if err != nil {
+		return err
+	}
+
+	check, err := client.CreateCheck(checkOptions)
+
+	if err != nil {
+		return fmt.Errorf("Can't create check: %w", err)
+	}
+
+	return render(check, checkRecords(check))
+}
+
+// updateCheck updates check
+func updateCheck(client *updown.Client, token string) error {
+	checkOptions, err := getCheckOptions()
+
+	if err != nil {
+		return err
+	}
+
+	check, err := client.UpdateCheck(token, checkOptions)
+
+	if err != nil {
+		return fmt.Errorf("Can't update check: %w", err)
+	}
+
+	return render(check, checkRecords(check))
+}
+
+// deleteCheck deletes check
+func deleteCheck(client *updown.Client, token string) error {
+	err := client.DeleteCheck(token)
+
+	if err != nil {
+		return fmt.Errorf("Can't delete check: %w", err)
+	}
+
+	fmtc.Printfn("{g}Check {*}%s{!*} successfully deleted{!}", token)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// resolveToken returns token of check with given token or alias
+func resolveToken(client *updown.Client, tokenOrAlias string) (string, error) {
+	checks, err := client.GetChecks()
+
+	if err != nil {
+		return "", fmt.Errorf("Can't get checks: %w", err)
+	}
+
+	check := checks.Get(tokenOrAlias)
+
+	if check == nil {
+		return "", fmt.Errorf("Can't find check with token or alias %q", tokenOrAlias)
+	}
+
+	return check.Token, nil
+}
+
+// getCheckOptions creates check options using command-line options
+func getCheckOptions() (updown.CheckOptions, error) {
+	result := updown.CheckOptions{
+		URL:         options.GetS(OPT_URL),
+		Alias:       options.GetS(OPT_ALIAS),
+		Period:      options.GetI(OPT_PERIOD),
+		Apdex:       options.GetF(OPT_APDEX),
+		StringMatch: options.GetS(OPT_MATCH),
+		HTTPVerb:    strings.ToUpper(options.GetS(OPT_VERB)),
+		HTTPBody:    options.GetS(OPT_BODY),
+		IsEnabled:   getFlag(OPT_ENABLE, OPT_DISABLE),
+		IsPublished: getFlag(OPT_PUBLISH, OPT_UNPUBLISH),
+	}
+
+	switch {
+	case options.Has(OPT_MUTE):
+		mute := options.GetS(OPT_MUTE)
+		result.MuteUntil = &mute
+	case options.GetB(OPT_UNMUTE):
+		mute := ""
+		result.MuteUntil = &mute
+	}
+
+	result.Recipients = splitList(options.GetS(OPT_RECIPIENT))
+	result.DisabledLocations = splitList(options.GetS(OPT_LOCATIONS))
+
+	headers, err := parseHeaders(options.GetS(OPT_HEADERS))
+
+	if err != nil {
+		return result, err
+	}
+
+	result.CustomHeaders = headers
+
+	return result, nil
+}
+
+// getMetricsOptions creates metrics options
+func getMetricsOptions(from, to, rng, group string) (updown.MetricsOptions, error) {
+	var err error
+	var result updown.MetricsOptions
+
+	if rng != "" {
+		result, err = updown.MetricsRange(rng)
+
+		if err != nil {
+			return result, fmt.Errorf("Can't parse metrics range: %w", err)
+		}
+	}
+
+	if from != "" {
+		result.From, err = parseDate(from)
+
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if to != "" {
+		result.To, err = parseDate(to)
+
+		if err != nil {
+			return result, err
+		}
+	}
+
+	result.GroupBy = updown.MetricsGroup(strings.ToLower(group))
+
+	return result, result.Validate()
+}
+
+// getFlag returns pointer to boolean value if one of given options is set
+func getFlag(enableOpt, disableOpt string) *bool {
+	var v bool
+
+	switch {
+	case options.GetB(enableOpt):
+		v = true
+	case options.GetB(disableOpt):
+		v = false
+	default:
+		return nil
+	}
+
+	return &v
+}
+
+// parseDate parses date in one of supported formats
+func parseDate(date string) (time.Time, error) {
+	for _, layout := range dateFormats {
+		t, err := time.ParseInLocation(layout, date, time.Local)
+
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("Can't parse date %q", date)
+}
+
+// parseHeaders parses comma-separated list of headers in "Name:Value" format
+func parseHeaders(data string) (map[string]string, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	result := map[string]string{}
+
+	for _, header := range strings.Split(data, ",") {
+		name, value, ok := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+
+		if !ok || name == "" {
+			return nil, fmt.Errorf("Invalid header %q (must be in \"Name:Value\" format)", header)
+		}
+
+		result[name] = strings.TrimSpace(value)
+	}
+
+	return result, nil
+}
+
+// parseExecArgs parses arguments of "pulse exec" command and returns pulse URL
+// and command to run
+func parseExecArgs(args []string, envURL string) (string, []string, error) {
+	sepIndex := slices.Index(args, "--")
+
+	if sepIndex == -1 || sepIndex == len(args)-1 {
+		return "", nil, fmt.Errorf("You must define command to run after \"--\"")
+	}
+
+	url := envURL
+
+	if sepIndex > 0 {
+		url = args[0]
+	}
+
+	if url == "" {
+		return "", nil, fmt.Errorf("You must define pulse URL as an argument or using %s environment variable", ENV_PULSE_URL)
+	}
+
+	return url, args[sepIndex+1:], nil
+}
+
+// splitList splits comma-separated list
+func splitList(data string) []string {
+	var result []string
+
+	for _, v := range strings.Split(data, ",") {
+		v = strings.TrimSpace(v)
+
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Error returns error message
+func (e exitError) Error() string {
+	return e.err.Error()
+}
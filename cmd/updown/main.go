@@ -0,0 +1,359 @@
+// Command updown is command-line tool for updown.io API
+package main
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/knf"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/terminal"
+	"github.com/essentialkaos/ek/v13/usage"
+
+	"github.com/essentialkaos/updown"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Basic application info
+const (
+	APP  = "updown"
+	VER  = "0.3.0"
+	DESC = "Command-line tool for updown.io"
+)
+
+// Options
+const (
+	OPT_FORMAT    = "f:format"
+	OPT_KEY       = "k:key"
+	OPT_CONFIG    = "c:config"
+	OPT_METRICS   = "m:metrics"
+	OPT_DETAILED  = "d:detailed"
+	OPT_FROM      = "F:from"
+	OPT_TO        = "T:to"
+	OPT_RANGE     = "R:range"
+	OPT_GROUP     = "G:group"
+	OPT_PAYLOAD   = "p:payload"
+	OPT_REPORT    = "r:report-failures"
+	OPT_TIMEOUT   = "t:timeout"
+	OPT_URL       = "url"
+	OPT_ALIAS     = "alias"
+	OPT_PERIOD    = "period"
+	OPT_APDEX     = "apdex"
+	OPT_ENABLE    = "enable"
+	OPT_DISABLE   = "disable"
+	OPT_PUBLISH   = "publish"
+	OPT_UNPUBLISH = "unpublish"
+	OPT_MUTE      = "mute"
+	OPT_UNMUTE    = "unmute"
+	OPT_MATCH     = "string-match"
+	OPT_VERB      = "http-verb"
+	OPT_BODY      = "http-body"
+	OPT_RECIPIENT = "recipients"
+	OPT_LOCATIONS = "disabled-locations"
+	OPT_HEADERS   = "headers"
+	OPT_NO_COLOR  = "nc:no-color"
+	OPT_HELP      = "h:help"
+	OPT_VER       = "v:version"
+)
+
+// Commands
+const (
+	CMD_CHECKS       = "checks"
+	CMD_DOWNTIMES    = "downtimes"
+	CMD_METRICS      = "metrics"
+	CMD_NODES        = "nodes"
+	CMD_RECIPIENTS   = "recipients"
+	CMD_STATUS_PAGES = "status-pages"
+	CMD_PULSE        = "pulse"
+)
+
+// Sub-commands
+const (
+	CMD_EXEC = "exec"
+
+	CMD_LIST   = "list"
+	CMD_SHOW   = "show"
+	CMD_CREATE = "create"
+	CMD_UPDATE = "update"
+	CMD_DELETE = "delete"
+)
+
+// ENV_API_KEY is name of environment variable with API key
+const ENV_API_KEY = "UPDOWN_API_KEY"
+
+// ENV_PULSE_URL is name of environment variable with pulse URL
+const ENV_PULSE_URL = "UPDOWN_PULSE_URL"
+
+// CONFIG_API_KEY is name of config property with API key
+const CONFIG_API_KEY = "api:key"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// optMap contains information about all supported options
+var optMap = options.Map{
+	OPT_FORMAT:    {Value: FORMAT_TABLE},
+	OPT_KEY:       {},
+	OPT_CONFIG:    {},
+	OPT_METRICS:   {Type: options.BOOL},
+	OPT_DETAILED:  {Type: options.BOOL},
+	OPT_FROM:      {},
+	OPT_TO:        {},
+	OPT_RANGE:     {Conflicts: []string{OPT_FROM, OPT_TO}},
+	OPT_GROUP:     {},
+	OPT_PAYLOAD:   {},
+	OPT_REPORT:    {Type: options.BOOL},
+	OPT_TIMEOUT:   {},
+	OPT_URL:       {},
+	OPT_ALIAS:     {},
+	OPT_PERIOD:    {Type: options.INT, Min: 15, Max: 3600},
+	OPT_APDEX:     {Type: options.FLOAT, Min: 0.125, Max: 8},
+	OPT_ENABLE:    {Type: options.BOOL, Conflicts: OPT_DISABLE},
+	OPT_DISABLE:   {Type: options.BOOL},
+	OPT_PUBLISH:   {Type: options.BOOL, Conflicts: OPT_UNPUBLISH},
+	OPT_UNPUBLISH: {Type: options.BOOL},
+	OPT_MUTE:      {Conflicts: OPT_UNMUTE},
+	OPT_UNMUTE:    {Type: options.BOOL},
+	OPT_MATCH:     {},
+	OPT_VERB:      {},
+	OPT_BODY:      {},
+	OPT_RECIPIENT: {},
+	OPT_LOCATIONS: {},
+	OPT_HEADERS:   {},
+	OPT_NO_COLOR:  {Type: options.BOOL},
+	OPT_HELP:      {Type: options.BOOL},
+	OPT_VER:       {Type: options.BOOL},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func main() {
+	args, errs := options.Parse(optMap)
+
+	if !errs.IsEmpty() {
+		terminal.Error("Options parsing errors:")
+		terminal.Error(errs.Error(" - "))
+		os.Exit(1)
+	}
+
+	if options.GetB(OPT_NO_COLOR) {
+		fmtc.DisableColors = true
+	}
+
+	switch {
+	case options.GetB(OPT_VER):
+		genAbout().Print()
+		os.Exit(0)
+	case options.GetB(OPT_HELP) || len(args) == 0:
+		genUsage().Print()
+		os.Exit(0)
+	}
+
+	err := process(args)
+
+	if err != nil {
+		var exitErr exitError
+
+		if errors.As(err, &exitErr) {
+			terminal.Error(exitErr.err)
+			os.Exit(exitErr.code)
+		}
+
+		terminal.Error(err)
+		os.Exit(1)
+	}
+}
+
+// process runs command
+func process(args options.Arguments) error {
+	format := options.GetS(OPT_FORMAT)
+
+	if !isValidFormat(format) {
+		return fmt.Errorf("Unsupported output format %q", format)
+	}
+
+	cmd := args.Get(0).ToLower().String()
+	args = args[1:]
+
+	if cmd == CMD_PULSE {
+		return cmdPulse(args)
+	}
+
+	client, err := getClient()
+
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case CMD_CHECKS:
+		return cmdChecks(client, args)
+	case CMD_DOWNTIMES:
+		return cmdDowntimes(client, args)
+	case CMD_METRICS:
+		return cmdMetrics(client, args)
+	case CMD_NODES:
+		return cmdNodes(client)
+	case CMD_RECIPIENTS:
+		return cmdRecipients(client)
+	case CMD_STATUS_PAGES:
+		return cmdStatusPages(client)
+	}
+
+	return fmt.Errorf("Unknown command %q", cmd)
+}
+
+// getClient creates API client
+func getClient() (*updown.Client, error) {
+	apiKey, err := getAPIKey(
+		options.GetS(OPT_KEY), os.Getenv(ENV_API_KEY),
+		getConfigPath(options.GetS(OPT_CONFIG)),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := updown.NewClient(apiKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	client.SetUserAgent(APP, VER)
+
+	return client, nil
+}
+
+// getAPIKey returns API key from options, environment variable or
+// configuration file
+func getAPIKey(optKey, envKey, configFile string) (string, error) {
+	switch {
+	case optKey != "":
+		return optKey, nil
+	case envKey != "":
+		return envKey, nil
+	case configFile == "":
+		return "", fmt.Errorf("API key is not set")
+	}
+
+	if _, err := os.Stat(configFile); err != nil {
+		return "", fmt.Errorf("API key is not set (use option %s, environment variable %s or configuration file)",
+			options.F(OPT_KEY), ENV_API_KEY,
+		)
+	}
+
+	cfg, err := knf.Read(configFile)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't read configuration file: %w", err)
+	}
+
+	apiKey := cfg.GetS(CONFIG_API_KEY)
+
+	if apiKey == "" {
+		return "", fmt.Errorf("Configuration file %s doesn't contain API key", configFile)
+	}
+
+	return apiKey, nil
+}
+
+// getConfigPath returns path to configuration file
+func getConfigPath(path string) string {
+	if path != "" {
+		return path
+	}
+
+	configDir, err := os.UserConfigDir()
+
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(configDir, "updown", "updown.knf")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// genUsage generates usage info
+func genUsage() *usage.Info {
+	info := usage.NewInfo()
+
+	info.AddCommand(CMD_CHECKS+" "+CMD_LIST, "List all checks")
+	info.AddCommand(CMD_CHECKS+" "+CMD_SHOW, "Show check info", "token-or-alias")
+	info.AddCommand(CMD_CHECKS+" "+CMD_CREATE, "Create new check")
+	info.AddCommand(CMD_CHECKS+" "+CMD_UPDATE, "Update check", "token-or-alias")
+	info.AddCommand(CMD_CHECKS+" "+CMD_DELETE, "Delete check", "token-or-alias")
+	info.AddCommand(CMD_DOWNTIMES, "List check downtimes", "token-or-alias")
+	info.AddCommand(CMD_METRICS, "Show check metrics", "token-or-alias")
+	info.AddCommand(CMD_NODES, "List monitoring nodes")
+	info.AddCommand(CMD_RECIPIENTS, "List alert recipients")
+	info.AddCommand(CMD_STATUS_PAGES, "List status pages")
+	info.AddCommand(CMD_PULSE, "Send pulse", "url")
+	info.AddCommand(CMD_PULSE+" "+CMD_EXEC, "Run command and send pulse if it succeeded", "?url", "--", "command…")
+
+	info.AddOption(OPT_FORMAT, "Output format {s-}(table/json/csv){!}", "format")
+	info.AddOption(OPT_KEY, "API key", "key")
+	info.AddOption(OPT_CONFIG, "Path to configuration file", "file")
+	info.AddOption(OPT_METRICS, "Show check metrics")
+	info.AddOption(OPT_DETAILED, "Show detailed downtimes info")
+	info.AddOption(OPT_FROM, "Metrics range start date", "date")
+	info.AddOption(OPT_TO, "Metrics range end date", "date")
+	info.AddOption(OPT_RANGE, "Metrics relative range {s-}(e.g. \"last 24h\"){!}", "range")
+	info.AddOption(OPT_GROUP, "Metrics grouping {s-}(time/host){!}", "group")
+	info.AddOption(OPT_PAYLOAD, "Pulse payload", "data")
+	info.AddOption(OPT_REPORT, "Send pulse with failure details if command failed {s-}(check will stay up){!}")
+	info.AddOption(OPT_TIMEOUT, "Command execution timeout {s-}(e.g. 30m){!}", "duration")
+	info.AddOption(OPT_URL, "Check URL", "url")
+	info.AddOption(OPT_ALIAS, "Check alias", "alias")
+	info.AddOption(OPT_PERIOD, "Check interval in seconds", "sec")
+	info.AddOption(OPT_APDEX, "Apdex threshold in seconds", "sec")
+	info.AddOption(OPT_ENABLE, "Enable check")
+	info.AddOption(OPT_DISABLE, "Disable check")
+	info.AddOption(OPT_PUBLISH, "Make check status page public")
+	info.AddOption(OPT_UNPUBLISH, "Make check status page private")
+	info.AddOption(OPT_MUTE, "Mute notifications {s-}(date, recovery or forever){!}", "until")
+	info.AddOption(OPT_UNMUTE, "Unmute notifications")
+	info.AddOption(OPT_MATCH, "String to search in response body", "string")
+	info.AddOption(OPT_VERB, "HTTP method", "method")
+	info.AddOption(OPT_BODY, "HTTP request body", "body")
+	info.AddOption(OPT_RECIPIENT, "Comma-separated list of recipients IDs", "ids")
+	info.AddOption(OPT_LOCATIONS, "Comma-separated list of disabled locations", "locations")
+	info.AddOption(OPT_HEADERS, "Comma-separated list of custom headers {s-}(Name:Value){!}", "headers")
+	info.AddOption(OPT_NO_COLOR, "Disable colors in output")
+	info.AddOption(OPT_HELP, "Show this help message")
+	info.AddOption(OPT_VER, "Show version")
+
+	info.AddEnv(ENV_API_KEY, "API key")
+	info.AddEnv(ENV_PULSE_URL, "Pulse URL for pulse exec command")
+
+	info.AddExample(CMD_CHECKS+" "+CMD_LIST+" -f json", "List all checks in JSON format")
+	info.AddExample(CMD_CHECKS+" "+CMD_CREATE+" --url https://domain.com --alias Domain --period 60", "Create new check")
+	info.AddExample(CMD_METRICS+" ngg8 --range 'last 24h' --group host", "Show metrics for the last 24 hours grouped by node")
+	info.AddExample(CMD_PULSE+" https://pulse.updown.io/ngg8/abcd", "Send pulse")
+	info.AddExample(CMD_PULSE+" "+CMD_EXEC+" https://pulse.updown.io/ngg8/abcd -- backup.sh --full", "Run backup script and send pulse if it succeeded")
+
+	return info
+}
+
+// genAbout generates info about version
+func genAbout() *usage.About {
+	return &usage.About{
+		App:     APP,
+		Version: VER,
+		Desc:    DESC,
+		Year:    2009,
+		Owner:   "ESSENTIAL KAOS",
+		License: "Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>",
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/updown"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type CLISuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&CLISuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *CLISuite) TestAPIKey(c *C) {
+	dir := c.MkDir()
+	config := filepath.Join(dir, "updown.knf")
+	emptyConfig := filepath.Join(dir, "empty.knf")
+
+	c.Assert(os.WriteFile(config, []byte("[api]\n  key: cfg1234\n"), 0600), IsNil)
+	c.Assert(os.WriteFile(emptyConfig, []byte("[api]\n"), 0600), IsNil)
+
+	key, err := getAPIKey("opt1234", "env1234", config)
+	c.Assert(err, IsNil)
+	c.Assert(key, Equals, "opt1234")
+
+	key, err = getAPIKey("", "env1234", config)
+	c.Assert(err, IsNil)
+	c.Assert(key, Equals, "env1234")
+
+	key, err = getAPIKey("", "", config)
+	c.Assert(err, IsNil)
+	c.Assert(key, Equals, "cfg1234")
+
+	_, err = getAPIKey("", "", emptyConfig)
+	c.Assert(err, NotNil)
+
+	_, err = getAPIKey("", "", filepath.Join(dir, "unknown.knf"))
+	c.Assert(err, NotNil)
+
+	_, err = getAPIKey("", "", "")
+	c.Assert(err, NotNil)
+
+	c.Assert(getConfigPath("/etc/updown.knf"), Equals, "/etc/updown.knf")
+	c.Assert(getConfigPath(""), Not(Equals), "")
+}
+
+func (s *CLISuite) TestParsers(c *C) {
+	headers, err := parseHeaders("X-Token: abcd, X-Env:prod")
+	c.Assert(err, IsNil)
+	c.Assert(headers, DeepEquals, map[string]string{"X-Token": "abcd", "X-Env": "prod"})
+
+	headers, err = parseHeaders("")
+	c.Assert(err, IsNil)
+	c.Assert(headers, IsNil)
+
+	_, err = parseHeaders("X-Token")
+	c.Assert(err, NotNil)
+
+	c.Assert(splitList(""), IsNil)
+	c.Assert(splitList("fra, syd,,"), DeepEquals, []string{"fra", "syd"})
+
+	d, err := parseDate("2025-01-22")
+	c.Assert(err, IsNil)
+	c.Assert(d.Format("2006-01-02 15:04"), Equals, "2025-01-22 00:00")
+
+	d, err = parseDate("2025-01-22T10:00:00Z")
+	c.Assert(err, IsNil)
+	c.Assert(d.Equal(time.Date(2025, 1, 22, 10, 0, 0, 0, time.UTC)), Equals, true)
+
+	_, err = parseDate("22/01/2025")
+	c.Assert(err, NotNil)
+
+	o, err := getMetricsOptions("2025-01-01", "2025-01-02", "", "HOST")
+	c.Assert(err, IsNil)
+	c.Assert(o.GroupBy, Equals, updown.GROUP_BY_HOST)
+	c.Assert(o.From.Day(), Equals, 1)
+	c.Assert(o.To.Day(), Equals, 2)
+
+	o, err = getMetricsOptions("", "", "last 24h", "")
+	c.Assert(err, IsNil)
+	c.Assert(o.To.Sub(o.From), Equals, 24*time.Hour)
+
+	_, err = getMetricsOptions("", "", "someday", "")
+	c.Assert(err, NotNil)
+	_, err = getMetricsOptions("test", "", "", "")
+	c.Assert(err, NotNil)
+	_, err = getMetricsOptions("", "test", "", "")
+	c.Assert(err, NotNil)
+	_, err = getMetricsOptions("", "", "", "node")
+	c.Assert(err, NotNil)
+}
+
+func (s *CLISuite) TestExecArgs(c *C) {
+	url, cmd, err := parseExecArgs([]string{"https://pulse.updown.io/ngg8/abcd", "--", "backup.sh", "--full"}, "")
+	c.Assert(err, IsNil)
+	c.Assert(url, Equals, "https://pulse.updown.io/ngg8/abcd")
+	c.Assert(cmd, DeepEquals, []string{"backup.sh", "--full"})
+
+	url, cmd, err = parseExecArgs([]string{"--", "backup.sh"}, "https://pulse.updown.io/ngg8/efgh")
+	c.Assert(err, IsNil)
+	c.Assert(url, Equals, "https://pulse.updown.io/ngg8/efgh")
+	c.Assert(cmd, DeepEquals, []string{"backup.sh"})
+
+	_, _, err = parseExecArgs([]string{"--", "backup.sh"}, "")
+	c.Assert(err, NotNil)
+	_, _, err = parseExecArgs([]string{"https://pulse.updown.io/ngg8/abcd", "--"}, "")
+	c.Assert(err, NotNil)
+	_, _, err = parseExecArgs([]string{"https://pulse.updown.io/ngg8/abcd"}, "")
+	c.Assert(err, NotNil)
+
+	err = exitError{3, fmt.Errorf("Command failed")}
+	c.Assert(err, ErrorMatches, "Command failed")
+}
+
+func (s *CLISuite) TestOutput(c *C) {
+	checks := updown.Checks{
+		{Token: "ngg8", Alias: "Updown", URL: "https://updown.io", Uptime: 99.9, Period: 60, IsEnabled: true},
+		{Token: "abcd", URL: "https://domain.com", IsEnabled: true, IsDown: true},
+		{Token: "efgh", URL: "https://example.com"},
+	}
+
+	recs := checksRecords(checks)
+
+	c.Assert(recs.Rows, HasLen, 3)
+	c.Assert(recs.Rows[0][3], Equals, "up")
+	c.Assert(recs.Rows[1][3], Equals, "down")
+	c.Assert(recs.Rows[2][3], Equals, "disabled")
+
+	var buf bytes.Buffer
+
+	c.Assert(write(&buf, FORMAT_CSV, checks, recs), IsNil)
+	c.Assert(buf.String(), Equals, "TOKEN,ALIAS,URL,STATUS,UPTIME,PERIOD,LAST STATUS,LAST CHECK\n"+
+		"ngg8,Updown,https://updown.io,up,99.9,60,0,\n"+
+		"abcd,,https://domain.com,down,0,0,0,\n"+
+		"efgh,,https://example.com,disabled,0,0,0,\n",
+	)
+
+	buf.Reset()
+
+	c.Assert(write(&buf, FORMAT_JSON, updown.Recipients{{ID: "email:1", Type: "email"}}, nil), IsNil)
+	c.Assert(buf.String(), Matches, `(?s)\[\n  \{\n    "id": "email:1",.*`)
+	c.Assert(write(&buf, "xml", nil, nil), NotNil)
+
+	c.Assert(isValidFormat(FORMAT_TABLE), Equals, true)
+	c.Assert(isValidFormat("xml"), Equals, false)
+
+	check := &updown.Check{
+		Token:     "ngg8",
+		SSL:       &updown.SSLStatus{TestedAt: updown.Date{Time: time.Now()}, IsValid: true},
+		Domain:    &updown.Domain{ExpiresAt: updown.Date{Time: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		Metrics:   &updown.Metrics{Apdex: 0.99},
+		IsEnabled: true,
+	}
+
+	c.Assert(checkRecords(check).Rows, HasLen, 21)
+
+	c.Assert(downtimesRecords(updown.Downtimes{{ID: "1", Duration: 90}}).Rows[0][3], Equals, "1m30s")
+	c.Assert(nodesRecords(updown.Nodes{"syd": {}, "fra": {}}).Rows[0][0], Equals, "fra")
+	c.Assert(recipientsRecords(updown.Recipients{{ID: "email:1"}}).Rows, HasLen, 1)
+	c.Assert(statusPagesRecords(updown.StatusPages{{Checks: []string{"a", "b"}}}).Rows[0][4], Equals, "a, b")
+
+	recs = metricsRecords("node", map[string]*updown.Metrics{
+		"syd": {Uptime: 100, Requests: &updown.RequestStats{Samples: 10}},
+		"fra": {Uptime: 99},
+		"lan": nil,
+	})
+
+	c.Assert(recs.Headers[0], Equals, "NODE")
+	c.Assert(recs.Rows, HasLen, 2)
+	c.Assert(recs.Rows[0][0], Equals, "fra")
+	c.Assert(recs.Rows[1][3], Equals, "10")
+}
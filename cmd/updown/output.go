@@ -0,0 +1,300 @@
+package main
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/fmtutil/table"
+	"github.com/essentialkaos/ek/v13/options"
+
+	"github.com/essentialkaos/updown"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Output formats
+const (
+	FORMAT_TABLE = "table"
+	FORMAT_JSON  = "json"
+	FORMAT_CSV   = "csv"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// records is tabular representation of data
+type records struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// render prints data in format set by options
+func render(data any, recs *records) error {
+	format := options.GetS(OPT_FORMAT)
+
+	if format == FORMAT_TABLE {
+		printTable(recs)
+		return nil
+	}
+
+	return write(os.Stdout, format, data, recs)
+}
+
+// write writes data in JSON or CSV format to given writer
+func write(w io.Writer, format string, data any, recs *records) error {
+	switch format {
+	case FORMAT_JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+
+	case FORMAT_CSV:
+		cw := csv.NewWriter(w)
+		cw.Write(recs.Headers)
+		cw.WriteAll(recs.Rows)
+		return cw.Error()
+	}
+
+	return fmt.Errorf("Unsupported output format %q", format)
+}
+
+// printTable prints records as a table
+func printTable(recs *records) {
+	if len(recs.Rows) == 0 {
+		fmt.Println("No data")
+		return
+	}
+
+	t := table.NewTable(recs.Headers...)
+
+	for _, row := range recs.Rows {
+		data := make([]any, len(row))
+
+		for i, v := range row {
+			data[i] = v
+		}
+
+		t.Add(data...)
+	}
+
+	t.Render()
+}
+
+// isValidFormat returns true if given output format is supported
+func isValidFormat(format string) bool {
+	switch format {
+	case FORMAT_TABLE, FORMAT_JSON, FORMAT_CSV:
+		return true
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checksRecords converts checks to records
+func checksRecords(checks updown.Checks) *records {
+	recs := &records{
+		Headers: []string{
+			"TOKEN", "ALIAS", "URL", "STATUS", "UPTIME", "PERIOD", "LAST STATUS", "LAST CHECK",
+		},
+	}
+
+	for _, c := range checks {
+		recs.Rows = append(recs.Rows, []string{
+			c.Token, c.Alias, c.URL, checkStatus(c),
+			formatFloat(c.Uptime), fmt.Sprint(c.Period),
+			fmt.Sprint(c.LastStatus), formatDate(c.LastCheckAt),
+		})
+	}
+
+	return recs
+}
+
+// checkRecords converts check info to records
+func checkRecords(c *updown.Check) *records {
+	recs := &records{Headers: []string{"PROPERTY", "VALUE"}}
+
+	add := func(name, value string) {
+		recs.Rows = append(recs.Rows, []string{name, value})
+	}
+
+	add("Token", c.Token)
+	add("Alias", c.Alias)
+	add("URL", c.URL)
+	add("Status", checkStatus(c))
+	add("Error", c.Error)
+	add("Uptime", formatFloat(c.Uptime))
+	add("Period", fmt.Sprint(c.Period))
+	add("Apdex threshold", formatFloat(c.Apdex))
+	add("Published", fmt.Sprint(c.IsPublished))
+	add("HTTP method", c.HTTPVerb)
+	add("String match", c.StringMatch)
+	add("Last status", fmt.Sprint(c.LastStatus))
+	add("Last check", formatDate(c.LastCheckAt))
+	add("Next check", formatDate(c.NextCheckAt))
+	add("Muted until", formatDate(c.MuteUntil))
+	add("Recipients", strings.Join(c.Recipients, ", "))
+	add("Disabled locations", strings.Join(c.DisabledLocations, ", "))
+
+	if c.SSL != nil && !c.SSL.TestedAt.IsZero() {
+		add("SSL valid", fmt.Sprint(c.SSL.IsValid))
+		add("SSL expires", formatDate(c.SSL.ExpiresAt))
+	}
+
+	if c.Domain != nil && !c.Domain.ExpiresAt.IsZero() {
+		add("Domain expires", formatDate(c.Domain.ExpiresAt))
+	}
+
+	if c.Metrics != nil {
+		add("Apdex", formatFloat(c.Metrics.Apdex))
+	}
+
+	return recs
+}
+
+// downtimesRecords converts downtimes to records
+func downtimesRecords(downtimes updown.Downtimes) *records {
+	recs := &records{
+		Headers: []string{"ID", "STARTED", "ENDED", "DURATION", "PARTIAL", "ERROR"},
+	}
+
+	for _, d := range downtimes {
+		recs.Rows = append(recs.Rows, []string{
+			d.ID, formatDate(d.StartedAt), formatDate(d.EndedAt),
+			(time.Duration(d.Duration) * time.Second).String(),
+			fmt.Sprint(d.IsPartial), d.Error,
+		})
+	}
+
+	return recs
+}
+
+// metricsRecords converts metrics with given names to records
+func metricsRecords(name string, metrics map[string]*updown.Metrics) *records {
+	recs := &records{
+		Headers: []string{
+			strings.ToUpper(name), "UPTIME", "APDEX", "SAMPLES", "FAILURES", "P50", "P90", "P99",
+		},
+	}
+
+	var keys []string
+
+	for k := range metrics {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		m := metrics[k]
+
+		if m == nil {
+			continue
+		}
+
+		var samples, failures int
+
+		if m.Requests != nil {
+			samples, failures = m.Requests.Samples, m.Requests.Failures
+		}
+
+		p := m.Percentiles()
+
+		recs.Rows = append(recs.Rows, []string{
+			k, formatFloat(m.Uptime), formatFloat(m.Apdex),
+			fmt.Sprint(samples), fmt.Sprint(failures),
+			p.P50.String(), p.P90.String(), p.P99.String(),
+		})
+	}
+
+	return recs
+}
+
+// nodesRecords converts nodes to records
+func nodesRecords(nodes updown.Nodes) *records {
+	recs := &records{
+		Headers: []string{"NAME", "CITY", "COUNTRY", "IP", "IPV6"},
+	}
+
+	var names []string
+
+	for name := range nodes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		n := nodes[name]
+		recs.Rows = append(recs.Rows, []string{name, n.City, n.Country, n.IP, n.IPv6})
+	}
+
+	return recs
+}
+
+// recipientsRecords converts recipients to records
+func recipientsRecords(recipients updown.Recipients) *records {
+	recs := &records{Headers: []string{"ID", "TYPE", "NAME", "VALUE"}}
+
+	for _, r := range recipients {
+		recs.Rows = append(recs.Rows, []string{r.ID, r.Type, r.Name, r.Value})
+	}
+
+	return recs
+}
+
+// statusPagesRecords converts status pages to records
+func statusPagesRecords(pages updown.StatusPages) *records {
+	recs := &records{Headers: []string{"TOKEN", "NAME", "URL", "VISIBILITY", "CHECKS"}}
+
+	for _, p := range pages {
+		recs.Rows = append(recs.Rows, []string{
+			p.Token, p.Name, p.URL, p.Visibility, strings.Join(p.Checks, ", "),
+		})
+	}
+
+	return recs
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkStatus returns human-readable check status
+func checkStatus(c *updown.Check) string {
+	switch {
+	case !c.IsEnabled:
+		return "disabled"
+	case c.IsDown:
+		return "down"
+	}
+
+	return "up"
+}
+
+// formatDate formats date using RFC3339 format
+func formatDate(d updown.Date) string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return d.UTC().Format(time.RFC3339)
+}
+
+// formatFloat formats float number without trailing zeros
+func formatFloat(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
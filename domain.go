@@ -0,0 +1,341 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// EVENT_DOMAIN_EXPIRATION is type of events generated by DomainEvaluator. These
+// events are generated by client only, updown.io never sends them, so they are
+// not supported by ParseWebhook.
+const EVENT_DOMAIN_EXPIRATION = "check.domain_expiration"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DomainReport contains info about domains of checks
+type DomainReport struct {
+	GeneratedAt Date                `json:"generated_at"`
+	Domains     []*DomainReportItem `json:"domains"`
+}
+
+// DomainReportItem contains info about registrable domain
+type DomainReportItem struct {
+	Name          string   `json:"name"`
+	ExpiresAt     Date     `json:"expires_at"`
+	RemainingDays int      `json:"remaining_days"`
+	Source        string   `json:"source"`
+	TestedAt      Date     `json:"tested_at"`
+	Checks        []string `json:"checks"`
+}
+
+// EventDomainExpiration generated by DomainEvaluator when domain approaches
+// expiration date
+type EventDomainExpiration struct {
+	Event
+	Domain *DomainExpiration `json:"domain"`
+}
+
+// DomainExpiration contains info about expiring domain
+type DomainExpiration struct {
+	Name          string `json:"name"`
+	ExpiresAt     Date   `json:"expires_at"`
+	RemainingDays int    `json:"remaining_days"`
+	Threshold     int    `json:"threshold"`
+	Source        string `json:"source"`
+}
+
+// DomainEvaluator generates domain expiration events when domains cross
+// expiration thresholds. Every threshold is reported only once per domain.
+type DomainEvaluator struct {
+	thresholds []int
+	notified   map[string]int
+	mu         sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DefaultDomainThresholds contains default domain expiration thresholds (in days)
+var DefaultDomainThresholds = []int{60, 30, 7}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewDomainReport creates report with info about domains of given checks.
+// Checks are de-duplicated by registrable domain (e.g. "api.example.co.uk"
+// and "www.example.co.uk" share "example.co.uk").
+func NewDomainReport(checks Checks) *DomainReport {
+	return newDomainReport(checks, time.Now())
+}
+
+// RegistrableDomain returns registrable domain (eTLD+1) for given URL
+func RegistrableDomain(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+
+	if err != nil {
+		return "", err
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+
+	switch {
+	case host == "":
+		return "", fmt.Errorf("URL %q has no host", rawURL)
+	case net.ParseIP(host) != nil:
+		return "", fmt.Errorf("URL %q contains IP address instead of domain", rawURL)
+	}
+
+	return publicsuffix.EffectiveTLDPlusOne(host)
+}
+
+// NewDomainEvaluator creates new domain expiration evaluator with given
+// thresholds (in days). DefaultDomainThresholds is used if no thresholds
+// are given.
+func NewDomainEvaluator(thresholds ...int) *DomainEvaluator {
+	if len(thresholds) == 0 {
+		thresholds = DefaultDomainThresholds
+	}
+
+	thresholds = slices.Clone(thresholds)
+	slices.Sort(thresholds)
+
+	return &DomainEvaluator{
+		thresholds: slices.Compact(thresholds),
+		notified:   map[string]int{},
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns info about domain with given name
+func (r *DomainReport) Get(name string) *DomainReportItem {
+	if r == nil {
+		return nil
+	}
+
+	for _, item := range r.Domains {
+		if strings.EqualFold(item.Name, name) {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// Expiring returns domains which expire within given number of days
+func (r *DomainReport) Expiring(days int) []*DomainReportItem {
+	if r == nil {
+		return nil
+	}
+
+	var result []*DomainReportItem
+
+	for _, item := range r.Domains {
+		if !item.ExpiresAt.IsZero() && item.RemainingDays <= days {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// WriteJSON writes report as JSON to given writer
+func (r *DomainReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// WriteCSV writes domains as CSV to given writer
+func (r *DomainReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	cw.Write([]string{
+		"name", "expires_at", "remaining_days", "source", "tested_at", "checks",
+	})
+
+	if r != nil {
+		for _, item := range r.Domains {
+			cw.Write([]string{
+				item.Name, formatReportDate(item.ExpiresAt),
+				strconv.Itoa(item.RemainingDays), item.Source,
+				formatReportDate(item.TestedAt), strings.Join(item.Checks, " "),
+			})
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Evaluate checks domains of given checks and returns events for domains which
+// crossed expiration thresholds since previous evaluation. Domains which were
+// renewed (remaining days is greater than the biggest threshold) can be
+// reported again.
+func (e *DomainEvaluator) Evaluate(checks Checks) Webhook {
+	if e == nil {
+		return nil
+	}
+
+	return e.evaluate(checks, time.Now())
+}
+
+// Reset resets info about reported thresholds
+func (e *DomainEvaluator) Reset() {
+	if e == nil {
+		return
+	}
+
+	e.mu.Lock()
+	e.notified = map[string]int{}
+	e.mu.Unlock()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// evaluate checks domains and generates events
+func (e *DomainEvaluator) evaluate(checks Checks, now time.Time) Webhook {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.notified == nil {
+		e.notified = map[string]int{}
+	}
+
+	if len(e.thresholds) == 0 {
+		e.thresholds = NewDomainEvaluator().thresholds
+	}
+
+	var result Webhook
+
+	report := newDomainReport(checks, now)
+	index := checks.Index()
+
+	for _, item := range report.Domains {
+		if item.ExpiresAt.IsZero() {
+			continue
+		}
+
+		threshold, ok := e.getThreshold(item.RemainingDays)
+
+		if !ok {
+			delete(e.notified, item.Name)
+			continue
+		}
+
+		prev, notified := e.notified[item.Name]
+
+		if notified && prev <= threshold {
+			continue
+		}
+
+		e.notified[item.Name] = threshold
+
+		result = append(result, &WebhookEvent{
+			Type: EVENT_DOMAIN_EXPIRATION,
+			Event: &EventDomainExpiration{
+				Event: Event{
+					Type:  EVENT_DOMAIN_EXPIRATION,
+					Time:  report.GeneratedAt,
+					Check: index.Token(item.Checks[0]),
+					Description: fmt.Sprintf(
+						"The domain %s will expire in %d days",
+						item.Name, item.RemainingDays,
+					),
+				},
+				Domain: &DomainExpiration{
+					Name:          item.Name,
+					ExpiresAt:     item.ExpiresAt,
+					RemainingDays: item.RemainingDays,
+					Threshold:     threshold,
+					Source:        item.Source,
+				},
+			},
+		})
+	}
+
+	return result
+}
+
+// getThreshold returns the smallest threshold for given number of days
+func (e *DomainEvaluator) getThreshold(days int) (int, bool) {
+	for _, t := range e.thresholds {
+		if days <= t {
+			return t, true
+		}
+	}
+
+	return 0, false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newDomainReport creates domain report
+func newDomainReport(checks Checks, now time.Time) *DomainReport {
+	report := &DomainReport{GeneratedAt: Date{now.UTC().Truncate(time.Second)}}
+	items := map[string]*DomainReportItem{}
+
+	for _, c := range checks {
+		if c == nil || c.Domain == nil {
+			continue
+		}
+
+		name, err := RegistrableDomain(c.URL)
+
+		if err != nil {
+			continue
+		}
+
+		item := items[name]
+
+		if item == nil {
+			item = &DomainReportItem{Name: name}
+			items[name] = item
+			report.Domains = append(report.Domains, item)
+		}
+
+		item.Checks = append(item.Checks, c.Token)
+
+		// Use the most recent domain info
+		if item.TestedAt.After(c.Domain.TestedAt.Time) {
+			continue
+		}
+
+		item.ExpiresAt = c.Domain.ExpiresAt
+		item.RemainingDays = c.Domain.RemainingDays
+		item.Source = c.Domain.Source
+		item.TestedAt = c.Domain.TestedAt
+	}
+
+	slices.SortStableFunc(report.Domains, func(a, b *DomainReportItem) int {
+		return cmp.Or(
+			cmp.Compare(a.RemainingDays, b.RemainingDays),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	return report
+}
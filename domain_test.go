@@ -0,0 +1,163 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestDomainReport(c *C) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	checks := Checks{
+		{
+			Token: "a001", URL: "https://api.example.co.uk/health",
+			Domain: &Domain{TestedAt: Date{now.Add(-day)}, ExpiresAt: Date{now.Add(40 * day)}, RemainingDays: 41, Source: SOURCE_WHOIS},
+		},
+		{
+			Token: "a002", URL: "https://WWW.Example.co.uk",
+			Domain: &Domain{TestedAt: Date{now}, ExpiresAt: Date{now.Add(40 * day)}, RemainingDays: 40, Source: SOURCE_RDAP},
+		},
+		{
+			Token: "a003", URL: "https://domain.com",
+			Domain: &Domain{TestedAt: Date{now}, ExpiresAt: Date{now.Add(300 * day)}, RemainingDays: 300, Source: SOURCE_RDAP},
+		},
+		{Token: "a004", URL: "https://unknown.org", Domain: &Domain{}},
+		{Token: "a005", URL: "https://127.0.0.1", Domain: &Domain{}},
+		{Token: "a006", URL: "https://nodomain.com"},
+		nil,
+	}
+
+	report := newDomainReport(checks, now)
+
+	c.Assert(report.Domains, HasLen, 3)
+	c.Assert(report.Domains[0].Name, Equals, "unknown.org")
+	c.Assert(report.Domains[1].Name, Equals, "example.co.uk")
+	c.Assert(report.Domains[1].Checks, DeepEquals, []string{"a001", "a002"})
+	c.Assert(report.Domains[1].RemainingDays, Equals, 40)
+	c.Assert(report.Domains[1].Source, Equals, SOURCE_RDAP)
+	c.Assert(report.Domains[2].Name, Equals, "domain.com")
+
+	c.Assert(report.Get("EXAMPLE.co.uk"), NotNil)
+	c.Assert(report.Get("unknown.com"), IsNil)
+	c.Assert(report.Expiring(30), HasLen, 0)
+	c.Assert(report.Expiring(60), HasLen, 1)
+
+	buf := &bytes.Buffer{}
+	c.Assert(report.WriteCSV(buf), IsNil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	c.Assert(lines, HasLen, 4)
+	c.Assert(lines[2], Equals, "example.co.uk,2025-04-10T12:00:00Z,40,RDAP,2025-03-01T12:00:00Z,a001 a002")
+
+	buf.Reset()
+	c.Assert(report.WriteJSON(buf), IsNil)
+
+	decoded := &DomainReport{}
+	c.Assert(json.Unmarshal(buf.Bytes(), decoded), IsNil)
+	c.Assert(decoded.Domains, HasLen, 3)
+
+	c.Assert(NewDomainReport(nil).Domains, HasLen, 0)
+
+	var nilReport *DomainReport
+
+	c.Assert(nilReport.Get("domain.com"), IsNil)
+	c.Assert(nilReport.Expiring(30), IsNil)
+	c.Assert(nilReport.WriteCSV(buf), IsNil)
+}
+
+func (s *UpdownSuite) TestDomainEvaluator(c *C) {
+	now := time.Now()
+	check := &Check{
+		Token: "a001", URL: "https://domain.com",
+		Domain: &Domain{ExpiresAt: Date{now.Add(100 * 24 * time.Hour)}, RemainingDays: 100},
+	}
+
+	checks := Checks{check, {Token: "a002", URL: "https://other.com", Domain: &Domain{}}}
+	e := NewDomainEvaluator()
+
+	c.Assert(e.thresholds, DeepEquals, []int{7, 30, 60})
+	c.Assert(e.Evaluate(checks), HasLen, 0)
+
+	check.Domain.RemainingDays = 45
+	events := e.Evaluate(checks)
+
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].Type, Equals, EVENT_DOMAIN_EXPIRATION)
+
+	ev := events[0].Event.(*EventDomainExpiration)
+
+	c.Assert(ev.Check, Equals, check)
+	c.Assert(ev.Description, Equals, "The domain domain.com will expire in 45 days")
+	c.Assert(ev.Domain.Threshold, Equals, 60)
+	c.Assert(ev.Domain.RemainingDays, Equals, 45)
+
+	c.Assert(e.Evaluate(checks), HasLen, 0)
+
+	check.Domain.RemainingDays = 5
+	events = e.Evaluate(checks)
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].Event.(*EventDomainExpiration).Domain.Threshold, Equals, 7)
+
+	check.Domain.RemainingDays = 20
+	c.Assert(e.Evaluate(checks), HasLen, 0)
+
+	check.Domain.RemainingDays = 365
+	c.Assert(e.Evaluate(checks), HasLen, 0)
+
+	check.Domain.RemainingDays = 50
+	c.Assert(e.Evaluate(checks), HasLen, 1)
+
+	e.Reset()
+	c.Assert(e.Evaluate(checks), HasLen, 1)
+
+	e = NewDomainEvaluator(10, 10, 3)
+	c.Assert(e.thresholds, DeepEquals, []int{3, 10})
+
+	data, err := json.Marshal([]any{events[0].Event})
+	c.Assert(err, IsNil)
+
+	// Domain events are generated by client and aren't parsed as webhooks
+	wh, err := ParseWebhook(data)
+	c.Assert(err, IsNil)
+	c.Assert(wh, HasLen, 0)
+
+	c.Assert((&DomainEvaluator{}).Evaluate(checks), HasLen, 1)
+
+	var nilEvaluator *DomainEvaluator
+
+	c.Assert(nilEvaluator.Evaluate(checks), IsNil)
+	nilEvaluator.Reset()
+}
+
+func (s *UpdownSuite) TestRegistrableDomain(c *C) {
+	d, err := RegistrableDomain("https://www.essentialkaos.com/test")
+	c.Assert(err, IsNil)
+	c.Assert(d, Equals, "essentialkaos.com")
+
+	d, err = RegistrableDomain("https://a.b.example.com.au.:8443")
+	c.Assert(err, IsNil)
+	c.Assert(d, Equals, "example.com.au")
+
+	_, err = RegistrableDomain("://")
+	c.Assert(err, NotNil)
+	_, err = RegistrableDomain("/path")
+	c.Assert(err, NotNil)
+	_, err = RegistrableDomain("https://[::1]:8080")
+	c.Assert(err, NotNil)
+	_, err = RegistrableDomain("https://com")
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,442 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	EXPORT_FORMAT_CSV   = "csv"
+	EXPORT_FORMAT_JSONL = "jsonl"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RecordWriter writes flattened checks, downtimes and metrics records to CSV
+// or JSON Lines stream. Every record type has stable schema, all timestamps
+// are formatted using RFC3339. Missing timestamps are written as null to JSON
+// Lines and as empty fields to CSV. CSV stream can contain records of only one
+// type.
+type RecordWriter struct {
+	format  string
+	csv     *csv.Writer
+	enc     *json.Encoder
+	columns []string
+}
+
+// CheckRecord is flattened check record
+type CheckRecord struct {
+	Token           string  `json:"token"`
+	Alias           string  `json:"alias"`
+	URL             string  `json:"url"`
+	IsEnabled       bool    `json:"enabled"`
+	IsPublished     bool    `json:"published"`
+	IsDown          bool    `json:"down"`
+	LastStatus      int     `json:"last_status"`
+	Uptime          float64 `json:"uptime"`
+	Apdex           float64 `json:"apdex_t"`
+	Period          int     `json:"period"`
+	Error           string  `json:"error"`
+	DownSince       *string `json:"down_since"`
+	LastCheckAt     *string `json:"last_check_at"`
+	NextCheckAt     *string `json:"next_check_at"`
+	CreatedAt       *string `json:"created_at"`
+	MuteUntil       *string `json:"mute_until"`
+	SSLValid        bool    `json:"ssl_valid"`
+	SSLExpiresAt    *string `json:"ssl_expires_at"`
+	DomainExpiresAt *string `json:"domain_expires_at"`
+}
+
+// DowntimeRecord is flattened downtime record
+type DowntimeRecord struct {
+	Token      string  `json:"token"`
+	Alias      string  `json:"alias"`
+	ID         string  `json:"id"`
+	Error      string  `json:"error"`
+	StartedAt  *string `json:"started_at"`
+	EndedAt    *string `json:"ended_at"`
+	Duration   int     `json:"duration"`
+	IsPartial  bool    `json:"partial"`
+	DetailsURL string  `json:"details_url"`
+}
+
+// MetricsRecord is flattened metrics point record
+type MetricsRecord struct {
+	Token      string  `json:"token"`
+	Alias      string  `json:"alias"`
+	Time       string  `json:"time"`
+	Uptime     float64 `json:"uptime"`
+	Apdex      float64 `json:"apdex"`
+	Samples    int     `json:"samples"`
+	Failures   int     `json:"failures"`
+	Satisfied  int     `json:"satisfied"`
+	Tolerated  int     `json:"tolerated"`
+	Redirect   int     `json:"redirect"`
+	NameLookup int     `json:"namelookup"`
+	Connection int     `json:"connection"`
+	Handshake  int     `json:"handshake"`
+	Response   int     `json:"response"`
+	Total      int     `json:"total"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	// CheckColumns contains columns of checks CSV records
+	CheckColumns = []string{
+		"token", "alias", "url", "enabled", "published", "down", "last_status",
+		"uptime", "apdex_t", "period", "error", "down_since", "last_check_at",
+		"next_check_at", "created_at", "mute_until", "ssl_valid", "ssl_expires_at",
+		"domain_expires_at",
+	}
+
+	// DowntimeColumns contains columns of downtimes CSV records
+	DowntimeColumns = []string{
+		"token", "alias", "id", "error", "started_at", "ended_at", "duration",
+		"partial", "details_url",
+	}
+
+	// MetricsColumns contains columns of metrics CSV records
+	MetricsColumns = []string{
+		"token", "alias", "time", "uptime", "apdex", "samples", "failures",
+		"satisfied", "tolerated", "redirect", "namelookup", "connection",
+		"handshake", "response", "total",
+	}
+)
+
+var (
+	ErrNilWriter         = errors.New("Writer is nil")
+	ErrUnsupportedFormat = errors.New("Unsupported export format")
+	ErrMixedRecords      = errors.New("CSV stream can't contain records of different types")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewRecordWriter creates new records writer for given format
+func NewRecordWriter(w io.Writer, format string) (*RecordWriter, error) {
+	switch {
+	case w == nil:
+		return nil, ErrNilWriter
+	case format == EXPORT_FORMAT_CSV:
+		return NewCSVWriter(w), nil
+	case format == EXPORT_FORMAT_JSONL:
+		return NewJSONLWriter(w), nil
+	}
+
+	return nil, ErrUnsupportedFormat
+}
+
+// NewCSVWriter creates new writer for CSV records
+func NewCSVWriter(w io.Writer) *RecordWriter {
+	return &RecordWriter{format: EXPORT_FORMAT_CSV, csv: csv.NewWriter(w)}
+}
+
+// NewJSONLWriter creates new writer for JSON Lines records
+func NewJSONLWriter(w io.Writer) *RecordWriter {
+	return &RecordWriter{format: EXPORT_FORMAT_JSONL, enc: json.NewEncoder(w)}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Record returns flattened check record
+func (c *Check) Record() *CheckRecord {
+	if c == nil {
+		return nil
+	}
+
+	r := &CheckRecord{
+		Token:       c.Token,
+		Alias:       c.Alias,
+		URL:         c.URL,
+		IsEnabled:   c.IsEnabled,
+		IsPublished: c.IsPublished,
+		IsDown:      c.IsDown,
+		LastStatus:  c.LastStatus,
+		Uptime:      c.Uptime,
+		Apdex:       c.Apdex,
+		Period:      c.Period,
+		Error:       c.Error,
+		DownSince:   getRecordDate(c.DownSince),
+		LastCheckAt: getRecordDate(c.LastCheckAt),
+		NextCheckAt: getRecordDate(c.NextCheckAt),
+		CreatedAt:   getRecordDate(c.CreatedAt),
+		MuteUntil:   getRecordDate(c.MuteUntil),
+	}
+
+	if c.SSL != nil {
+		r.SSLValid = c.SSL.IsValid
+		r.SSLExpiresAt = getRecordDate(c.SSL.ExpiresAt)
+	}
+
+	if c.Domain != nil {
+		r.DomainExpiresAt = getRecordDate(c.Domain.ExpiresAt)
+	}
+
+	return r
+}
+
+// Records returns flattened downtime records for given check
+func (d Downtimes) Records(check *Check) []*DowntimeRecord {
+	var result []*DowntimeRecord
+
+	token, alias := getCheckIDs(check)
+
+	for _, dd := range d {
+		if dd == nil {
+			continue
+		}
+
+		result = append(result, &DowntimeRecord{
+			Token:      token,
+			Alias:      alias,
+			ID:         dd.ID,
+			Error:      dd.Error,
+			StartedAt:  getRecordDate(dd.StartedAt),
+			EndedAt:    getRecordDate(dd.EndedAt),
+			Duration:   dd.Duration,
+			IsPartial:  dd.IsPartial,
+			DetailsURL: dd.DetailsURL,
+		})
+	}
+
+	return result
+}
+
+// Records returns flattened metrics records for given check
+func (s MetricsSeries) Records(check *Check) []*MetricsRecord {
+	var result []*MetricsRecord
+
+	token, alias := getCheckIDs(check)
+
+	for _, p := range s {
+		if p == nil {
+			continue
+		}
+
+		r := &MetricsRecord{
+			Token: token,
+			Alias: alias,
+			Time:  formatReportDate(Date{p.Time}),
+		}
+
+		if p.Metrics != nil {
+			r.Uptime = p.Metrics.Uptime
+			r.Apdex = p.Metrics.Apdex
+
+			if p.Metrics.Requests != nil {
+				r.Samples = p.Metrics.Requests.Samples
+				r.Failures = p.Metrics.Requests.Failures
+				r.Satisfied = p.Metrics.Requests.Satisfied
+				r.Tolerated = p.Metrics.Requests.Tolerated
+			}
+
+			if p.Metrics.Timings != nil {
+				r.Redirect = p.Metrics.Timings.Redirect
+				r.NameLookup = p.Metrics.Timings.NameLookup
+				r.Connection = p.Metrics.Timings.Connection
+				r.Handshake = p.Metrics.Timings.Handshake
+				r.Response = p.Metrics.Timings.Response
+				r.Total = p.Metrics.Timings.Total
+			}
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WriteChecks writes checks records
+func (w *RecordWriter) WriteChecks(checks Checks) error {
+	if w == nil {
+		return ErrNilWriter
+	}
+
+	err := w.header(CheckColumns)
+
+	if err != nil {
+		return err
+	}
+
+	for _, c := range checks {
+		if c == nil {
+			continue
+		}
+
+		r := c.Record()
+		err = w.write(r, func() []string {
+			return []string{
+				r.Token, r.Alias, r.URL, formatBool(r.IsEnabled),
+				formatBool(r.IsPublished), formatBool(r.IsDown),
+				strconv.Itoa(r.LastStatus), formatFloat(r.Uptime),
+				formatFloat(r.Apdex), strconv.Itoa(r.Period), r.Error,
+				formatString(r.DownSince), formatString(r.LastCheckAt),
+				formatString(r.NextCheckAt), formatString(r.CreatedAt),
+				formatString(r.MuteUntil), formatBool(r.SSLValid),
+				formatString(r.SSLExpiresAt), formatString(r.DomainExpiresAt),
+			}
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.flush()
+}
+
+// WriteDowntimes writes downtimes records for given check
+func (w *RecordWriter) WriteDowntimes(check *Check, downtimes Downtimes) error {
+	if w == nil {
+		return ErrNilWriter
+	}
+
+	err := w.header(DowntimeColumns)
+
+	if err != nil {
+		return err
+	}
+
+	for _, r := range downtimes.Records(check) {
+		err = w.write(r, func() []string {
+			return []string{
+				r.Token, r.Alias, r.ID, r.Error, formatString(r.StartedAt),
+				formatString(r.EndedAt),
+				strconv.Itoa(r.Duration), formatBool(r.IsPartial), r.DetailsURL,
+			}
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.flush()
+}
+
+// WriteMetrics writes metrics records for given check
+func (w *RecordWriter) WriteMetrics(check *Check, series MetricsSeries) error {
+	if w == nil {
+		return ErrNilWriter
+	}
+
+	err := w.header(MetricsColumns)
+
+	if err != nil {
+		return err
+	}
+
+	for _, r := range series.Records(check) {
+		err = w.write(r, func() []string {
+			return []string{
+				r.Token, r.Alias, r.Time, formatFloat(r.Uptime),
+				formatFloat(r.Apdex), strconv.Itoa(r.Samples),
+				strconv.Itoa(r.Failures), strconv.Itoa(r.Satisfied),
+				strconv.Itoa(r.Tolerated), strconv.Itoa(r.Redirect),
+				strconv.Itoa(r.NameLookup), strconv.Itoa(r.Connection),
+				strconv.Itoa(r.Handshake), strconv.Itoa(r.Response),
+				strconv.Itoa(r.Total),
+			}
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.flush()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// header writes CSV header if it wasn't written before and checks that stream
+// doesn't contain records of different types
+func (w *RecordWriter) header(columns []string) error {
+	switch {
+	case w.format == EXPORT_FORMAT_JSONL:
+		return nil
+	case w.columns == nil:
+		w.columns = columns
+		return w.csv.Write(columns)
+	case strings.Join(w.columns, ",") != strings.Join(columns, ","):
+		return ErrMixedRecords
+	}
+
+	return nil
+}
+
+// write writes single record to stream
+func (w *RecordWriter) write(record any, fields func() []string) error {
+	if w.format == EXPORT_FORMAT_JSONL {
+		return w.enc.Encode(record)
+	}
+
+	return w.csv.Write(fields())
+}
+
+// flush flushes buffered CSV data
+func (w *RecordWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+
+	w.csv.Flush()
+
+	return w.csv.Error()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getCheckIDs returns check token and alias
+func getCheckIDs(check *Check) (string, string) {
+	if check == nil {
+		return "", ""
+	}
+
+	return check.Token, check.Alias
+}
+
+// getRecordDate returns formatted date or nil if date is empty
+func getRecordDate(d Date) *string {
+	if d.IsZero() {
+		return nil
+	}
+
+	v := formatReportDate(d)
+
+	return &v
+}
+
+// formatString formats optional string value for CSV
+func formatString(v *string) string {
+	if v == nil {
+		return ""
+	}
+
+	return *v
+}
+
+// formatBool formats boolean value for CSV
+func formatBool(v bool) string {
+	return strconv.FormatBool(v)
+}
+
+// formatFloat formats float value for CSV
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,171 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestExportChecks(c *C) {
+	ts := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	checks := Checks{
+		{
+			Token: "a001", Alias: "API", URL: "https://api.example.com", IsEnabled: true,
+			Uptime: 99.95, Apdex: 0.5, Period: 60, LastCheckAt: Date{ts},
+			SSL:    &SSLStatus{IsValid: true, ExpiresAt: Date{ts.AddDate(0, 1, 0)}},
+			Domain: &Domain{ExpiresAt: Date{ts.AddDate(1, 0, 0)}},
+		},
+		nil,
+		{Token: "a002", URL: "https://www.example.com", Error: "Timeout, 30s"},
+	}
+
+	var buf bytes.Buffer
+
+	c.Assert(NewCSVWriter(&buf).WriteChecks(checks), IsNil)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+
+	c.Assert(err, IsNil)
+	c.Assert(records, HasLen, 3)
+	c.Assert(records[0], DeepEquals, CheckColumns)
+	c.Assert(records[1], DeepEquals, []string{
+		"a001", "API", "https://api.example.com", "true", "false", "false", "0",
+		"99.95", "0.5", "60", "", "", "2025-03-01T10:00:00Z", "", "", "", "true",
+		"2025-04-01T10:00:00Z", "2026-03-01T10:00:00Z",
+	})
+	c.Assert(records[2][10], Equals, "Timeout, 30s")
+
+	buf.Reset()
+
+	c.Assert(NewJSONLWriter(&buf).WriteChecks(checks), IsNil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	c.Assert(lines, HasLen, 2)
+
+	rec := &CheckRecord{}
+
+	c.Assert(json.Unmarshal([]byte(lines[0]), rec), IsNil)
+	c.Assert(rec.Token, Equals, "a001")
+	c.Assert(*rec.LastCheckAt, Equals, "2025-03-01T10:00:00Z")
+	c.Assert(rec.DownSince, IsNil)
+	c.Assert(rec.SSLValid, Equals, true)
+	c.Assert(lines[0], Matches, `.*"down_since":null,"last_check_at":"2025-03-01T10:00:00Z",.*`)
+	c.Assert(lines[1], Matches, `.*"ssl_expires_at":null,"domain_expires_at":null\}`)
+
+	buf.Reset()
+
+	c.Assert(NewCSVWriter(&buf).WriteChecks(nil), IsNil)
+	c.Assert(buf.String(), Equals, strings.Join(CheckColumns, ",")+"\n")
+
+	buf.Reset()
+
+	c.Assert(NewJSONLWriter(&buf).WriteChecks(nil), IsNil)
+	c.Assert(buf.String(), Equals, "")
+
+	var nilCheck *Check
+
+	c.Assert(nilCheck.Record(), IsNil)
+}
+
+func (s *UpdownSuite) TestExportDowntimes(c *C) {
+	ts := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	check := &Check{Token: "a001", Alias: "API"}
+	downtimes := Downtimes{
+		{ID: "d1", Error: "500", StartedAt: Date{ts}, EndedAt: Date{ts.Add(time.Minute)}, Duration: 60},
+		nil,
+		{ID: "d2", StartedAt: Date{ts.Add(time.Hour)}, IsPartial: true},
+	}
+
+	var buf bytes.Buffer
+
+	w, err := NewRecordWriter(&buf, EXPORT_FORMAT_CSV)
+
+	c.Assert(err, IsNil)
+	c.Assert(w.WriteDowntimes(check, downtimes), IsNil)
+	c.Assert(w.WriteDowntimes(&Check{Token: "a002"}, downtimes[:1]), IsNil)
+	c.Assert(buf.String(), Equals, "token,alias,id,error,started_at,ended_at,duration,partial,details_url\n"+
+		"a001,API,d1,500,2025-03-01T10:00:00Z,2025-03-01T10:01:00Z,60,false,\n"+
+		"a001,API,d2,,2025-03-01T11:00:00Z,,0,true,\n"+
+		"a002,,d1,500,2025-03-01T10:00:00Z,2025-03-01T10:01:00Z,60,false,\n",
+	)
+
+	c.Assert(w.WriteChecks(Checks{check}), Equals, ErrMixedRecords)
+
+	buf.Reset()
+
+	w, err = NewRecordWriter(&buf, EXPORT_FORMAT_JSONL)
+
+	c.Assert(err, IsNil)
+	c.Assert(w.WriteDowntimes(nil, downtimes[2:]), IsNil)
+	c.Assert(w.WriteChecks(Checks{check}), IsNil)
+	c.Assert(buf.String(), Matches, `(?s)\{"token":"","alias":"","id":"d2",.*"ended_at":null,.*"partial":true,.*\}\n\{"token":"a001",.*\}\n`)
+
+	buf.Reset()
+
+	c.Assert(NewCSVWriter(&buf).WriteDowntimes(check, nil), IsNil)
+	c.Assert(buf.String(), Equals, strings.Join(DowntimeColumns, ",")+"\n")
+}
+
+func (s *UpdownSuite) TestExportMetrics(c *C) {
+	ts := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	check := &Check{Token: "a001", Alias: "API"}
+	series := MetricsSeries{
+		{
+			Time: ts,
+			Metrics: &Metrics{
+				Uptime: 100, Apdex: 0.98,
+				Requests: &RequestStats{Samples: 60, Failures: 1, Satisfied: 58, Tolerated: 1},
+				Timings:  &TimingStats{NameLookup: 5, Connection: 10, Handshake: 20, Response: 100, Total: 135},
+			},
+		},
+		nil,
+		{Time: ts.Add(time.Hour)},
+	}
+
+	var buf bytes.Buffer
+
+	c.Assert(NewCSVWriter(&buf).WriteMetrics(check, series), IsNil)
+	c.Assert(buf.String(), Equals, strings.Join(MetricsColumns, ",")+"\n"+
+		"a001,API,2025-03-01T10:00:00Z,100,0.98,60,1,58,1,0,5,10,20,100,135\n"+
+		"a001,API,2025-03-01T11:00:00Z,0,0,0,0,0,0,0,0,0,0,0,0\n",
+	)
+
+	buf.Reset()
+
+	c.Assert(NewJSONLWriter(&buf).WriteMetrics(check, series), IsNil)
+	c.Assert(strings.Count(buf.String(), "\n"), Equals, 2)
+	c.Assert(buf.String(), Matches, `(?s)\{"token":"a001","alias":"API","time":"2025-03-01T10:00:00Z","uptime":100,"apdex":0.98,.*`)
+
+	buf.Reset()
+
+	c.Assert(NewCSVWriter(&buf).WriteMetrics(check, MetricsSeries{}), IsNil)
+	c.Assert(buf.String(), Equals, strings.Join(MetricsColumns, ",")+"\n")
+}
+
+func (s *UpdownSuite) TestExportErrors(c *C) {
+	_, err := NewRecordWriter(nil, EXPORT_FORMAT_CSV)
+	c.Assert(err, Equals, ErrNilWriter)
+
+	_, err = NewRecordWriter(&bytes.Buffer{}, "parquet")
+	c.Assert(err, Equals, ErrUnsupportedFormat)
+
+	var w *RecordWriter
+
+	c.Assert(w.WriteChecks(nil), Equals, ErrNilWriter)
+	c.Assert(w.WriteDowntimes(nil, nil), Equals, ErrNilWriter)
+	c.Assert(w.WriteMetrics(nil, nil), Equals, ErrNilWriter)
+}
@@ -0,0 +1,358 @@
+// Package exporter provides Prometheus collector for updown.io checks
+package exporter
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/essentialkaos/updown"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_NAMESPACE is default metrics namespace
+const DEFAULT_NAMESPACE = "updown"
+
+// DEFAULT_CACHE_INTERVAL is default interval between API requests
+const DEFAULT_CACHE_INTERVAL = time.Minute
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Config contains collector configuration
+type Config struct {
+	// Namespace is metrics namespace
+	Namespace string
+
+	// CacheInterval is minimal interval between API requests. Scrapes made within
+	// this interval use cached data.
+	CacheInterval time.Duration
+
+	// WithMetrics enables fetching detailed metrics (Apdex, timings and response
+	// time histogram) for every check. Note that it requires one API request per
+	// check.
+	WithMetrics bool
+}
+
+// Collector is Prometheus collector for updown.io checks
+type Collector struct {
+	config Config
+	fetch  func() (updown.Checks, error)
+
+	mu        sync.Mutex
+	checks    updown.Checks
+	fetchedAt time.Time
+	fetchErr  error
+
+	up              *prometheus.Desc
+	uptime          *prometheus.Desc
+	apdex           *prometheus.Desc
+	apdexThreshold  *prometheus.Desc
+	lastStatus      *prometheus.Desc
+	enabled         *prometheus.Desc
+	sslValid        *prometheus.Desc
+	sslExpiryDays   *prometheus.Desc
+	domainDays      *prometheus.Desc
+	timings         *prometheus.Desc
+	responseTime    *prometheus.Desc
+	scrapeSuccess   *prometheus.Desc
+	scrapeTimestamp *prometheus.Desc
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilClient = errors.New("Client is nil")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkLabels is a list of labels used for all check metrics
+var checkLabels = []string{"token", "alias", "url"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewCollector creates new collector instance
+func NewCollector(client *updown.Client, config Config) (*Collector, error) {
+	if client == nil {
+		return nil, ErrNilClient
+	}
+
+	c := newCollector(config)
+	c.fetch = func() (updown.Checks, error) {
+		return fetchChecks(client, c.config.WithMetrics)
+	}
+
+	return c, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Describe sends descriptors of all metrics to the given channel
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.up
+	ch <- c.uptime
+	ch <- c.apdex
+	ch <- c.apdexThreshold
+	ch <- c.lastStatus
+	ch <- c.enabled
+	ch <- c.sslValid
+	ch <- c.sslExpiryDays
+	ch <- c.domainDays
+	ch <- c.timings
+	ch <- c.responseTime
+	ch <- c.scrapeSuccess
+	ch <- c.scrapeTimestamp
+}
+
+// Collect fetches data from API (if cached data is outdated) and sends metrics
+// to the given channel
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	checks, fetchedAt, err := c.getChecks()
+
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 0)
+	} else {
+		ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 1)
+	}
+
+	if fetchedAt.IsZero() {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.scrapeTimestamp, prometheus.GaugeValue, float64(fetchedAt.Unix()),
+	)
+
+	for _, check := range checks {
+		c.collectCheck(ch, check, fetchedAt)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getChecks returns cached checks or fetches them from API
+func (c *Collector) getChecks() (updown.Checks, time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fetchedAt.IsZero() && time.Since(c.fetchedAt) < c.config.CacheInterval {
+		return c.checks, c.fetchedAt, c.fetchErr
+	}
+
+	checks, err := c.fetch()
+
+	if err != nil {
+		// Keep serving previous data until the next successful fetch
+		c.fetchErr = err
+		return c.checks, c.fetchedAt, err
+	}
+
+	c.checks, c.fetchedAt, c.fetchErr = checks, time.Now(), nil
+
+	return c.checks, c.fetchedAt, nil
+}
+
+// collectCheck sends metrics for given check
+func (c *Collector) collectCheck(ch chan<- prometheus.Metric, check *updown.Check, now time.Time) {
+	if check == nil {
+		return
+	}
+
+	labels := []string{check.Token, check.Alias, check.URL}
+
+	ch <- gauge(c.up, boolValue(!check.IsDown), labels...)
+	ch <- gauge(c.uptime, check.Uptime, labels...)
+	ch <- gauge(c.apdexThreshold, check.Apdex, labels...)
+	ch <- gauge(c.lastStatus, float64(check.LastStatus), labels...)
+	ch <- gauge(c.enabled, boolValue(check.IsEnabled), labels...)
+
+	if check.SSL != nil && !check.SSL.TestedAt.IsZero() {
+		ch <- gauge(c.sslValid, boolValue(check.SSL.IsValid), labels...)
+
+		if !check.SSL.ExpiresAt.IsZero() {
+			ch <- gauge(c.sslExpiryDays, check.SSL.ExpiresAt.Sub(now).Hours()/24, labels...)
+		}
+	}
+
+	if check.Domain != nil && !check.Domain.ExpiresAt.IsZero() {
+		ch <- gauge(c.domainDays, float64(check.Domain.RemainingDays), labels...)
+	}
+
+	if check.Metrics == nil {
+		return
+	}
+
+	ch <- gauge(c.apdex, check.Metrics.Apdex, labels...)
+
+	if check.Metrics.Timings != nil {
+		t := check.Metrics.Timings
+
+		for phase, value := range map[string]int{
+			"redirect":   t.Redirect,
+			"namelookup": t.NameLookup,
+			"connection": t.Connection,
+			"handshake":  t.Handshake,
+			"response":   t.Response,
+			"total":      t.Total,
+		} {
+			ch <- gauge(c.timings, msToSeconds(value), append(labels, phase)...)
+		}
+	}
+
+	if check.Metrics.Requests != nil && check.Metrics.Requests.ByResponseTime != nil {
+		ch <- c.histogram(check.Metrics, labels)
+	}
+}
+
+// histogram creates response time histogram
+func (c *Collector) histogram(metrics *updown.Metrics, labels []string) prometheus.Metric {
+	rt := metrics.Requests.ByResponseTime
+	counters := []int{
+		rt.Under125, rt.Under250, rt.Under500,
+		rt.Under1k, rt.Under2k, rt.Under4k,
+		rt.Under8k, rt.Under16k, rt.Under32k,
+	}
+
+	var count uint64
+
+	buckets := make(map[float64]uint64, len(counters))
+
+	for i, bound := range []float64{0.125, 0.25, 0.5, 1, 2, 4, 8, 16, 32} {
+		// Buckets which are not present in API response get counter from
+		// the previous bucket
+		count = max(count, uint64(max(counters[i], 0)))
+		buckets[bound] = count
+	}
+
+	// There is no info about total response time, so sum is estimated
+	// using average total time
+	var sum float64
+
+	if metrics.Timings != nil {
+		sum = msToSeconds(metrics.Timings.Total) * float64(count)
+	}
+
+	return prometheus.MustNewConstHistogram(c.responseTime, count, sum, buckets, labels...)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newCollector creates new collector without data source
+func newCollector(config Config) *Collector {
+	if config.Namespace == "" {
+		config.Namespace = DEFAULT_NAMESPACE
+	}
+
+	if config.CacheInterval <= 0 {
+		config.CacheInterval = DEFAULT_CACHE_INTERVAL
+	}
+
+	ns := config.Namespace
+
+	return &Collector{
+		config: config,
+
+		up: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "check", "up"),
+			"Whether the check is up (1) or down (0)", checkLabels, nil,
+		),
+		uptime: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "check", "uptime_percent"),
+			"Check uptime in percents", checkLabels, nil,
+		),
+		apdex: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "check", "apdex"),
+			"Check Apdex score", checkLabels, nil,
+		),
+		apdexThreshold: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "check", "apdex_threshold_seconds"),
+			"Check Apdex threshold", checkLabels, nil,
+		),
+		lastStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "check", "last_status"),
+			"HTTP status code of the last check", checkLabels, nil,
+		),
+		enabled: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "check", "enabled"),
+			"Whether the check is enabled (1) or disabled (0)", checkLabels, nil,
+		),
+		sslValid: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "check", "ssl_valid"),
+			"Whether the SSL certificate is valid (1) or not (0)", checkLabels, nil,
+		),
+		sslExpiryDays: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "check", "ssl_expiry_days"),
+			"Number of days until SSL certificate expiration", checkLabels, nil,
+		),
+		domainDays: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "check", "domain_remaining_days"),
+			"Number of days until domain expiration", checkLabels, nil,
+		),
+		timings: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "check", "timing_seconds"),
+			"Average duration of request phase", append(checkLabels, "phase"), nil,
+		),
+		responseTime: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "check", "response_time_seconds"),
+			"Check response time", checkLabels, nil,
+		),
+		scrapeSuccess: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "exporter", "scrape_success"),
+			"Whether the last API request was successful (1) or not (0)", nil, nil,
+		),
+		scrapeTimestamp: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, "exporter", "scrape_timestamp_seconds"),
+			"Date of the last successful API request", nil, nil,
+		),
+	}
+}
+
+// fetchChecks fetches checks from API
+func fetchChecks(client *updown.Client, withMetrics bool) (updown.Checks, error) {
+	checks, err := client.GetChecks()
+
+	if err != nil || !withMetrics {
+		return checks, err
+	}
+
+	for i, check := range checks {
+		check, err = client.GetCheck(check.Token, true)
+
+		if err != nil {
+			return nil, err
+		}
+
+		checks[i] = check
+	}
+
+	return checks, nil
+}
+
+// gauge creates new gauge metric
+func gauge(desc *prometheus.Desc, value float64, labels ...string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+}
+
+// boolValue converts boolean to metric value
+func boolValue(v bool) float64 {
+	if v {
+		return 1
+	}
+
+	return 0
+}
+
+// msToSeconds converts milliseconds to seconds
+func msToSeconds(ms int) float64 {
+	return float64(ms) / 1000
+}
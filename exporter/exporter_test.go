@@ -0,0 +1,154 @@
+package exporter
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/essentialkaos/updown"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type ExporterSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&ExporterSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *ExporterSuite) TestNewCollector(c *C) {
+	_, err := NewCollector(nil, Config{})
+	c.Assert(err, Equals, ErrNilClient)
+
+	client, _ := updown.NewClient("test1234")
+	col, err := NewCollector(client, Config{})
+
+	c.Assert(err, IsNil)
+	c.Assert(col, NotNil)
+	c.Assert(col.config.Namespace, Equals, DEFAULT_NAMESPACE)
+	c.Assert(col.config.CacheInterval, Equals, DEFAULT_CACHE_INTERVAL)
+}
+
+func (s *ExporterSuite) TestCollect(c *C) {
+	col := newCollector(Config{Namespace: "test"})
+	col.fetch = func() (updown.Checks, error) {
+		return updown.Checks{getTestCheck(), nil}, nil
+	}
+
+	c.Assert(testutil.CollectAndCount(col), Equals, 18)
+
+	err := testutil.CollectAndCompare(col, strings.NewReader(`
+# HELP test_check_up Whether the check is up (1) or down (0)
+# TYPE test_check_up gauge
+test_check_up{alias="Updown",token="ngg8",url="https://updown.io"} 1
+# HELP test_check_last_status HTTP status code of the last check
+# TYPE test_check_last_status gauge
+test_check_last_status{alias="Updown",token="ngg8",url="https://updown.io"} 200
+# HELP test_check_domain_remaining_days Number of days until domain expiration
+# TYPE test_check_domain_remaining_days gauge
+test_check_domain_remaining_days{alias="Updown",token="ngg8",url="https://updown.io"} 758
+# HELP test_check_timing_seconds Average duration of request phase
+# TYPE test_check_timing_seconds gauge
+test_check_timing_seconds{alias="Updown",phase="connection",token="ngg8",url="https://updown.io"} 0.088
+test_check_timing_seconds{alias="Updown",phase="handshake",token="ngg8",url="https://updown.io"} 0.183
+test_check_timing_seconds{alias="Updown",phase="namelookup",token="ngg8",url="https://updown.io"} 0.009
+test_check_timing_seconds{alias="Updown",phase="redirect",token="ngg8",url="https://updown.io"} 0
+test_check_timing_seconds{alias="Updown",phase="response",token="ngg8",url="https://updown.io"} 0.09
+test_check_timing_seconds{alias="Updown",phase="total",token="ngg8",url="https://updown.io"} 0.37
+# HELP test_check_response_time_seconds Check response time
+# TYPE test_check_response_time_seconds histogram
+test_check_response_time_seconds_bucket{alias="Updown",token="ngg8",url="https://updown.io",le="0.125"} 70521
+test_check_response_time_seconds_bucket{alias="Updown",token="ngg8",url="https://updown.io",le="0.25"} 71126
+test_check_response_time_seconds_bucket{alias="Updown",token="ngg8",url="https://updown.io",le="0.5"} 87357
+test_check_response_time_seconds_bucket{alias="Updown",token="ngg8",url="https://updown.io",le="1"} 87422
+test_check_response_time_seconds_bucket{alias="Updown",token="ngg8",url="https://updown.io",le="2"} 87434
+test_check_response_time_seconds_bucket{alias="Updown",token="ngg8",url="https://updown.io",le="4"} 87438
+test_check_response_time_seconds_bucket{alias="Updown",token="ngg8",url="https://updown.io",le="8"} 87438
+test_check_response_time_seconds_bucket{alias="Updown",token="ngg8",url="https://updown.io",le="16"} 87438
+test_check_response_time_seconds_bucket{alias="Updown",token="ngg8",url="https://updown.io",le="32"} 87438
+test_check_response_time_seconds_bucket{alias="Updown",token="ngg8",url="https://updown.io",le="+Inf"} 87438
+test_check_response_time_seconds_sum{alias="Updown",token="ngg8",url="https://updown.io"} 32352.06
+test_check_response_time_seconds_count{alias="Updown",token="ngg8",url="https://updown.io"} 87438
+`), "test_check_up", "test_check_last_status", "test_check_domain_remaining_days",
+		"test_check_timing_seconds", "test_check_response_time_seconds",
+	)
+
+	c.Assert(err, IsNil)
+}
+
+func (s *ExporterSuite) TestCache(c *C) {
+	var calls int
+	var fetchErr error
+
+	col := newCollector(Config{CacheInterval: time.Hour})
+	col.fetch = func() (updown.Checks, error) {
+		calls++
+		return updown.Checks{{Token: "ngg8", IsEnabled: true}}, fetchErr
+	}
+
+	c.Assert(testutil.CollectAndCount(col, "updown_check_up"), Equals, 1)
+	c.Assert(testutil.CollectAndCount(col, "updown_check_up"), Equals, 1)
+	c.Assert(calls, Equals, 1)
+
+	// Force cache expiration
+	col.fetchedAt = time.Now().Add(-2 * time.Hour)
+	fetchErr = errors.New("Error")
+
+	c.Assert(testutil.CollectAndCount(col, "updown_check_up"), Equals, 1)
+	c.Assert(col.fetchErr, NotNil)
+	c.Assert(calls, Equals, 2)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func getTestCheck() *updown.Check {
+	return &updown.Check{
+		Token:      "ngg8",
+		Alias:      "Updown",
+		URL:        "https://updown.io",
+		LastStatus: 200,
+		Uptime:     99.9,
+		Apdex:      0.5,
+		IsEnabled:  true,
+		SSL: &updown.SSLStatus{
+			TestedAt:  updown.Date{Time: time.Now()},
+			ExpiresAt: updown.Date{Time: time.Now().Add(30 * 24 * time.Hour)},
+			IsValid:   true,
+		},
+		Domain: &updown.Domain{
+			ExpiresAt:     updown.Date{Time: time.Now().Add(758 * 24 * time.Hour)},
+			RemainingDays: 758,
+		},
+		Metrics: &updown.Metrics{
+			Uptime: 99.999,
+			Apdex:  0.999,
+			Timings: &updown.TimingStats{
+				NameLookup: 9, Connection: 88, Handshake: 183,
+				Response: 90, Total: 370,
+			},
+			Requests: &updown.RequestStats{
+				Samples: 87441,
+				ByResponseTime: &updown.ResponseTimeStats{
+					Under125: 70521, Under250: 71126, Under500: 87357,
+					Under1k: 87422, Under2k: 87434, Under4k: 87438,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,310 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"cmp"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SortField is field used for sorting checks
+type SortField string
+
+const (
+	SORT_BY_TOKEN      SortField = "token"
+	SORT_BY_ALIAS      SortField = "alias"
+	SORT_BY_UPTIME     SortField = "uptime"
+	SORT_BY_APDEX      SortField = "apdex"
+	SORT_BY_LAST_CHECK SortField = "last_check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CheckFilter is function which returns true if check matches filter
+type CheckFilter func(c *Check) bool
+
+// ChecksIndex is index for fast lookup of checks by token or alias
+type ChecksIndex struct {
+	byToken map[string]*Check
+	byAlias map[string]*Check
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// FilterHost returns filter which matches checks with URL host matching given
+// glob pattern (e.g. "*.example.com"). Matching is case-insensitive.
+func FilterHost(pattern string) CheckFilter {
+	pattern = strings.ToLower(pattern)
+
+	return func(c *Check) bool {
+		u, err := url.Parse(c.URL)
+
+		if err != nil {
+			return false
+		}
+
+		ok, _ := path.Match(pattern, strings.ToLower(u.Hostname()))
+
+		return ok
+	}
+}
+
+// FilterURL returns filter which matches checks with URL matching given
+// regular expression
+func FilterURL(re *regexp.Regexp) CheckFilter {
+	return func(c *Check) bool {
+		return re != nil && re.MatchString(c.URL)
+	}
+}
+
+// FilterAlias returns filter which matches checks with alias matching given glob
+// pattern. Matching is case-insensitive.
+func FilterAlias(pattern string) CheckFilter {
+	pattern = strings.ToLower(pattern)
+
+	return func(c *Check) bool {
+		ok, _ := path.Match(pattern, strings.ToLower(c.Alias))
+		return ok
+	}
+}
+
+// FilterEnabled returns filter which matches enabled or disabled checks
+func FilterEnabled(enabled bool) CheckFilter {
+	return func(c *Check) bool {
+		return c.IsEnabled == enabled
+	}
+}
+
+// FilterPublished returns filter which matches published or unpublished checks
+func FilterPublished(published bool) CheckFilter {
+	return func(c *Check) bool {
+		return c.IsPublished == published
+	}
+}
+
+// FilterDown returns filter which matches checks which are down or up
+func FilterDown(down bool) CheckFilter {
+	return func(c *Check) bool {
+		return c.IsDown == down
+	}
+}
+
+// FilterMuted returns filter which matches muted or unmuted checks
+func FilterMuted(muted bool) CheckFilter {
+	return func(c *Check) bool {
+		return c.IsMuted() == muted
+	}
+}
+
+// FilterRecipient returns filter which matches checks with given alert recipient
+func FilterRecipient(id string) CheckFilter {
+	return func(c *Check) bool {
+		return slices.Contains(c.Recipients, id)
+	}
+}
+
+// FilterDisabledLocation returns filter which matches checks with given disabled
+// location
+func FilterDisabledLocation(location string) CheckFilter {
+	return func(c *Check) bool {
+		return slices.Contains(c.DisabledLocations, location)
+	}
+}
+
+// FilterCustomHeader returns filter which matches checks with given custom header.
+// If value is empty, only header presence is checked.
+func FilterCustomHeader(name, value string) CheckFilter {
+	return func(c *Check) bool {
+		for k, v := range c.CustomHeaders {
+			if strings.EqualFold(k, name) && (value == "" || v == value) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// FilterPeriod returns filter which matches checks with given check period
+// (in seconds)
+func FilterPeriod(period int) CheckFilter {
+	return func(c *Check) bool {
+		return c.Period == period
+	}
+}
+
+// FilterNot returns filter which inverts given filter
+func FilterNot(filter CheckFilter) CheckFilter {
+	return func(c *Check) bool {
+		return !filter(c)
+	}
+}
+
+// FilterAny returns filter which matches checks matching any of given filters
+func FilterAny(filters ...CheckFilter) CheckFilter {
+	return func(c *Check) bool {
+		for _, f := range filters {
+			if f(c) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Filter returns checks matching all given filters
+func (c Checks) Filter(filters ...CheckFilter) Checks {
+	return c.filter(func(cc *Check) bool {
+		for _, f := range filters {
+			if f != nil && !f(cc) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// Select returns checks with given tokens or aliases
+func (c Checks) Select(tokensOrAliases ...string) Checks {
+	var result Checks
+
+	index := c.Index()
+
+	for _, id := range tokensOrAliases {
+		cc := index.Get(id)
+
+		if cc != nil && !slices.Contains(result, cc) {
+			result = append(result, cc)
+		}
+	}
+
+	return result
+}
+
+// Sort returns copy of checks sorted by given field. Checks without metrics
+// are placed first when sorting by Apdex in ascending order.
+func (c Checks) Sort(field SortField, desc bool) Checks {
+	result := slices.Clone(c)
+
+	slices.SortStableFunc(result, func(a, b *Check) int {
+		r := compareChecks(a, b, field)
+
+		if desc {
+			return -r
+		}
+
+		return r
+	})
+
+	return result
+}
+
+// Index creates index for fast lookup of checks by token or alias
+func (c Checks) Index() *ChecksIndex {
+	index := &ChecksIndex{
+		byToken: make(map[string]*Check, len(c)),
+		byAlias: make(map[string]*Check, len(c)),
+	}
+
+	for _, cc := range c {
+		if cc == nil {
+			continue
+		}
+
+		index.byToken[strings.ToLower(cc.Token)] = cc
+
+		alias := strings.ToLower(cc.Alias)
+
+		if alias != "" && index.byAlias[alias] == nil {
+			index.byAlias[alias] = cc
+		}
+	}
+
+	return index
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns check with given token or alias
+func (i *ChecksIndex) Get(tokenOrAlias string) *Check {
+	if i == nil || tokenOrAlias == "" {
+		return nil
+	}
+
+	tokenOrAlias = strings.ToLower(tokenOrAlias)
+
+	if c := i.byToken[tokenOrAlias]; c != nil {
+		return c
+	}
+
+	return i.byAlias[tokenOrAlias]
+}
+
+// Token returns check with given token
+func (i *ChecksIndex) Token(token string) *Check {
+	if i == nil {
+		return nil
+	}
+
+	return i.byToken[strings.ToLower(token)]
+}
+
+// Alias returns check with given alias
+func (i *ChecksIndex) Alias(alias string) *Check {
+	if i == nil {
+		return nil
+	}
+
+	return i.byAlias[strings.ToLower(alias)]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// compareChecks compares two checks by given field
+func compareChecks(a, b *Check, field SortField) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	switch field {
+	case SORT_BY_ALIAS:
+		return cmp.Compare(strings.ToLower(a.Alias), strings.ToLower(b.Alias))
+	case SORT_BY_UPTIME:
+		return cmp.Compare(a.Uptime, b.Uptime)
+	case SORT_BY_APDEX:
+		return cmp.Compare(getApdex(a), getApdex(b))
+	case SORT_BY_LAST_CHECK:
+		return a.LastCheckAt.Compare(b.LastCheckAt.Time)
+	}
+
+	return cmp.Compare(a.Token, b.Token)
+}
+
+// getApdex returns check Apdex or -1 if check has no metrics
+func getApdex(c *Check) float64 {
+	if c.Metrics == nil {
+		return -1
+	}
+
+	return c.Metrics.Apdex
+}
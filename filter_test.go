@@ -0,0 +1,143 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"regexp"
+	"time"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestFilter(c *C) {
+	now := time.Now()
+
+	checks := Checks{
+		{
+			Token: "a001", URL: "https://api.example.com/health", Alias: "API",
+			IsEnabled: true, IsPublished: true, Period: 30,
+			Recipients:    []string{"email:1"},
+			CustomHeaders: map[string]string{"X-Token": "abcd"},
+		},
+		{
+			Token: "a002", URL: "https://www.Example.com", Alias: "Website",
+			IsEnabled: true, IsDown: true, Period: 60,
+			Recipients:        []string{"email:1", "slack:2"},
+			DisabledLocations: []string{"lan", "mia"},
+			MuteUntil:         Date{now.Add(time.Hour)},
+		},
+		{
+			Token: "a003", URL: "https://example.org", Alias: "Landing",
+			Period: 60,
+		},
+		nil,
+	}
+
+	ids := func(checks Checks) []string {
+		var result []string
+
+		for _, c := range checks {
+			result = append(result, c.Token)
+		}
+
+		return result
+	}
+
+	c.Assert(checks.Filter(), HasLen, 3)
+	c.Assert(ids(checks.Filter(FilterHost("*.example.com"))), DeepEquals, []string{"a001", "a002"})
+	c.Assert(ids(checks.Filter(FilterHost("example.org"))), DeepEquals, []string{"a003"})
+	c.Assert(ids(checks.Filter(FilterURL(regexp.MustCompile(`/health$`)))), DeepEquals, []string{"a001"})
+	c.Assert(checks.Filter(FilterURL(nil)), HasLen, 0)
+	c.Assert(ids(checks.Filter(FilterAlias("web*"))), DeepEquals, []string{"a002"})
+	c.Assert(ids(checks.Filter(FilterEnabled(false))), DeepEquals, []string{"a003"})
+	c.Assert(ids(checks.Filter(FilterPublished(true))), DeepEquals, []string{"a001"})
+	c.Assert(ids(checks.Filter(FilterDown(true))), DeepEquals, []string{"a002"})
+	c.Assert(ids(checks.Filter(FilterMuted(true))), DeepEquals, []string{"a002"})
+	c.Assert(ids(checks.Filter(FilterRecipient("slack:2"))), DeepEquals, []string{"a002"})
+	c.Assert(ids(checks.Filter(FilterDisabledLocation("mia"))), DeepEquals, []string{"a002"})
+	c.Assert(ids(checks.Filter(FilterCustomHeader("x-token", ""))), DeepEquals, []string{"a001"})
+	c.Assert(checks.Filter(FilterCustomHeader("x-token", "1234")), HasLen, 0)
+	c.Assert(ids(checks.Filter(FilterPeriod(60))), DeepEquals, []string{"a002", "a003"})
+	c.Assert(ids(checks.Filter(FilterNot(FilterDown(true)))), DeepEquals, []string{"a001", "a003"})
+	c.Assert(ids(checks.Filter(FilterAny(FilterPeriod(30), FilterEnabled(false)))), DeepEquals, []string{"a001", "a003"})
+
+	c.Assert(ids(checks.Filter(
+		FilterHost("*.example.com"),
+		FilterEnabled(true),
+		FilterDown(true),
+		FilterRecipient("email:1"),
+	)), DeepEquals, []string{"a002"})
+
+	c.Assert(Checks{{URL: "://"}}.Filter(FilterHost("*")), HasLen, 0)
+}
+
+func (s *UpdownSuite) TestSort(c *C) {
+	now := time.Now()
+
+	checks := Checks{
+		{Token: "a002", Alias: "b", Uptime: 99, LastCheckAt: Date{now}, Metrics: &Metrics{Apdex: 0.9}},
+		{Token: "a001", Alias: "C", Uptime: 95, LastCheckAt: Date{now.Add(-time.Minute)}},
+		{Token: "a003", Alias: "a", Uptime: 100, LastCheckAt: Date{now.Add(-time.Hour)}, Metrics: &Metrics{Apdex: 0.5}},
+	}
+
+	ids := func(checks Checks) []string {
+		var result []string
+
+		for _, c := range checks {
+			if c != nil {
+				result = append(result, c.Token)
+			}
+		}
+
+		return result
+	}
+
+	c.Assert(ids(checks.Sort(SORT_BY_TOKEN, false)), DeepEquals, []string{"a001", "a002", "a003"})
+	c.Assert(ids(checks.Sort(SORT_BY_ALIAS, false)), DeepEquals, []string{"a003", "a002", "a001"})
+	c.Assert(ids(checks.Sort(SORT_BY_UPTIME, false)), DeepEquals, []string{"a001", "a002", "a003"})
+	c.Assert(ids(checks.Sort(SORT_BY_UPTIME, true)), DeepEquals, []string{"a003", "a002", "a001"})
+	c.Assert(ids(checks.Sort(SORT_BY_APDEX, false)), DeepEquals, []string{"a001", "a003", "a002"})
+	c.Assert(ids(checks.Sort(SORT_BY_LAST_CHECK, true)), DeepEquals, []string{"a002", "a001", "a003"})
+	c.Assert(checks[0].Token, Equals, "a002")
+
+	c.Assert(ids(append(checks, nil).Sort(SORT_BY_UPTIME, false)), HasLen, 3)
+	c.Assert(compareChecks(nil, nil, SORT_BY_UPTIME), Equals, 0)
+	c.Assert(compareChecks(checks[0], nil, SORT_BY_UPTIME), Equals, 1)
+}
+
+func (s *UpdownSuite) TestIndex(c *C) {
+	checks := Checks{
+		{Token: "a001", Alias: "API"},
+		{Token: "a002", Alias: "Website"},
+		{Token: "a003", Alias: "api"},
+		{Token: "a004"},
+		nil,
+	}
+
+	index := checks.Index()
+
+	c.Assert(index.Get(""), IsNil)
+	c.Assert(index.Get("A002").Token, Equals, "a002")
+	c.Assert(index.Get("website").Token, Equals, "a002")
+	c.Assert(index.Get("api").Token, Equals, "a001")
+	c.Assert(index.Get("unknown"), IsNil)
+	c.Assert(index.Token("a004").Token, Equals, "a004")
+	c.Assert(index.Token("api"), IsNil)
+	c.Assert(index.Alias("WEBSITE").Token, Equals, "a002")
+	c.Assert(index.Alias("a001"), IsNil)
+
+	c.Assert(checks.Get("WEBSITE").Token, Equals, "a002")
+
+	var nilIndex *ChecksIndex
+
+	c.Assert(nilIndex.Get("a001"), IsNil)
+	c.Assert(nilIndex.Token("a001"), IsNil)
+	c.Assert(nilIndex.Alias("api"), IsNil)
+}
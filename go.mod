@@ -5,6 +5,7 @@ go 1.24.9
 require (
 	github.com/essentialkaos/check v1.4.1
 	github.com/essentialkaos/ek/v13 v13.36.1
+	golang.org/x/net v0.43.0
 )
 
 require (
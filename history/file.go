@@ -0,0 +1,345 @@
+package history
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SEGMENT_EXT is extension of segment files
+const SEGMENT_EXT = ".jsonl"
+
+// segmentLayout is layout of segment file name (one segment per day)
+const segmentLayout = "2006-01-02"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// FileStore is file-based store which keeps snapshots in daily JSON Lines
+// segments (one snapshot per line)
+type FileStore struct {
+	dir    string
+	mu     sync.RWMutex
+	closed bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrEmptyDir = errors.New("Store directory path is empty")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// OpenFileStore opens file-based store in given directory. Directory will be
+// created if it doesn't exist.
+func OpenFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, ErrEmptyDir
+	}
+
+	err := os.MkdirAll(dir, 0750)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't create store directory: %w", err)
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Save appends snapshot to the segment of the snapshot day
+func (s *FileStore) Save(snapshot *Snapshot) error {
+	switch {
+	case s == nil:
+		return ErrNilStore
+	case snapshot == nil || snapshot.Time.IsZero():
+		return ErrEmptySnapshot
+	}
+
+	data, err := json.Marshal(snapshot)
+
+	if err != nil {
+		return fmt.Errorf("Can't encode snapshot: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	fd, err := os.OpenFile(
+		s.segmentPath(snapshot.Time), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0640,
+	)
+
+	if err != nil {
+		return fmt.Errorf("Can't open segment: %w", err)
+	}
+
+	// If the last line was partially written (e.g. during crash), we have to
+	// terminate it, otherwise new snapshot will be glued to the damaged line
+	if !isTerminated(fd) {
+		data = append([]byte{'\n'}, data...)
+	}
+
+	_, err = fd.Write(append(data, '\n'))
+
+	if err == nil {
+		err = fd.Sync()
+	}
+
+	fd.Close()
+
+	if err != nil {
+		return fmt.Errorf("Can't write snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// StateAt returns the latest snapshot taken at or before given time
+func (s *FileStore) StateAt(t time.Time) (*Snapshot, error) {
+	if s == nil {
+		return nil, ErrNilStore
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	segments, err := s.segments()
+
+	if err != nil {
+		return nil, err
+	}
+
+	day := t.UTC().Format(segmentLayout)
+
+	// Segments are sorted by day, so we can stop on the first segment which
+	// contains suitable snapshot
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] > day {
+			continue
+		}
+
+		var result *Snapshot
+
+		err = s.readSegment(segments[i], func(snapshot *Snapshot) {
+			if !snapshot.Time.After(t) && (result == nil || !snapshot.Time.Before(result.Time)) {
+				result = snapshot
+			}
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if result != nil {
+			return result, nil
+		}
+	}
+
+	return nil, ErrSnapshotNotFound
+}
+
+// Range returns snapshots taken within given period sorted by time
+func (s *FileStore) Range(from, to time.Time) ([]*Snapshot, error) {
+	switch {
+	case s == nil:
+		return nil, ErrNilStore
+	case to.Before(from):
+		return nil, ErrInvalidRange
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	segments, err := s.segments()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Snapshot
+
+	fromDay, toDay := from.UTC().Format(segmentLayout), to.UTC().Format(segmentLayout)
+
+	for _, segment := range segments {
+		if segment < fromDay || segment > toDay {
+			continue
+		}
+
+		err = s.readSegment(segment, func(snapshot *Snapshot) {
+			if !snapshot.Time.Before(from) && !snapshot.Time.After(to) {
+				result = append(result, snapshot)
+			}
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b *Snapshot) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return result, nil
+}
+
+// Prune removes segments with snapshots taken before given date. Segment is
+// removed only if all its snapshots are older than given date.
+func (s *FileStore) Prune(before time.Time) error {
+	if s == nil {
+		return ErrNilStore
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	segments, err := s.segments()
+
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, segment := range segments {
+		day, _ := time.Parse(segmentLayout, segment)
+
+		if !day.AddDate(0, 0, 1).After(before) {
+			err = os.Remove(filepath.Join(s.dir, segment+SEGMENT_EXT))
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("Can't remove segment %q: %w", segment, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close closes the store
+func (s *FileStore) Close() error {
+	if s == nil {
+		return ErrNilStore
+	}
+
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// segmentPath returns path to segment for given time
+func (s *FileStore) segmentPath(t time.Time) string {
+	return filepath.Join(s.dir, t.UTC().Format(segmentLayout)+SEGMENT_EXT)
+}
+
+// segments returns sorted slice with names (days) of segments
+func (s *FileStore) segments() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read store directory: %w", err)
+	}
+
+	var result []string
+
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), SEGMENT_EXT)
+
+		if !ok || e.IsDir() {
+			continue
+		}
+
+		if _, err := time.Parse(segmentLayout, name); err == nil {
+			result = append(result, name)
+		}
+	}
+
+	slices.Sort(result)
+
+	return result, nil
+}
+
+// isTerminated returns true if segment is empty or ends with newline
+func isTerminated(fd *os.File) bool {
+	info, err := fd.Stat()
+
+	if err != nil || info.Size() == 0 {
+		return true
+	}
+
+	buf := make([]byte, 1)
+	_, err = fd.ReadAt(buf, info.Size()-1)
+
+	return err != nil || buf[0] == '\n'
+}
+
+// readSegment reads all snapshots from segment
+func (s *FileStore) readSegment(segment string, fn func(snapshot *Snapshot)) error {
+	fd, err := os.Open(filepath.Join(s.dir, segment+SEGMENT_EXT))
+
+	if err != nil {
+		return fmt.Errorf("Can't open segment %q: %w", segment, err)
+	}
+
+	defer fd.Close()
+
+	r := bufio.NewReader(fd)
+
+	for {
+		data, err := r.ReadBytes('\n')
+
+		if len(data) != 0 {
+			snapshot := &Snapshot{}
+
+			// Damaged lines (e.g. partially written during crash) are skipped
+			if json.Unmarshal(data, snapshot) == nil && !snapshot.Time.IsZero() {
+				fn(snapshot)
+			}
+		}
+
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return fmt.Errorf("Can't read segment %q: %w", segment, err)
+		}
+	}
+}
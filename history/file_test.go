@@ -0,0 +1,157 @@
+package history
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/essentialkaos/updown"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *HistorySuite) TestFileStore(c *C) {
+	dir := c.MkDir()
+	store, err := OpenFileStore(dir)
+
+	c.Assert(err, IsNil)
+
+	var _ Store = store
+
+	ts := time.Date(2025, 3, 1, 22, 0, 0, 0, time.UTC)
+
+	for i := range 6 {
+		err = store.Save(&Snapshot{
+			Time: ts.Add(time.Duration(i) * time.Hour),
+			Checks: updown.Checks{
+				{Token: "a001", Uptime: 100 - float64(i), LastCheckAt: updown.Date{Time: ts}},
+			},
+		})
+
+		c.Assert(err, IsNil)
+	}
+
+	c.Assert(store.Save(nil), Equals, ErrEmptySnapshot)
+	c.Assert(store.Save(&Snapshot{}), Equals, ErrEmptySnapshot)
+
+	entries, _ := os.ReadDir(dir)
+	c.Assert(entries, HasLen, 2)
+
+	// Damaged line and unrelated files must be ignored
+	fd, _ := os.OpenFile(filepath.Join(dir, "2025-03-02.jsonl"), os.O_WRONLY|os.O_APPEND, 0640)
+	fd.WriteString(`{"time":"2025-03-02T05:00:00Z","checks":[{"tok`)
+	fd.Close()
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("test"), 0640)
+	os.WriteFile(filepath.Join(dir, "backup.jsonl"), []byte("test"), 0640)
+
+	// Snapshot saved after damaged line must be readable
+	err = store.Save(&Snapshot{
+		Time:   ts.Add(6 * time.Hour),
+		Checks: updown.Checks{{Token: "a001", Uptime: 90}},
+	})
+
+	c.Assert(err, IsNil)
+
+	state, err := store.StateAt(ts.Add(150 * time.Minute))
+
+	c.Assert(err, IsNil)
+	c.Assert(state.Time, Equals, ts.Add(2*time.Hour))
+	c.Assert(state.Checks[0].Uptime, Equals, 98.0)
+	c.Assert(state.Checks[0].LastCheckAt.Time, Equals, ts)
+
+	state, err = store.StateAt(ts.Add(time.Hour + 30*time.Minute))
+
+	c.Assert(err, IsNil)
+	c.Assert(state.Time, Equals, ts.Add(time.Hour))
+
+	state, err = store.StateAt(ts.Add(48 * time.Hour))
+
+	c.Assert(err, IsNil)
+	c.Assert(state.Time, Equals, ts.Add(6*time.Hour))
+	c.Assert(state.Checks[0].Uptime, Equals, 90.0)
+
+	state, err = store.StateAt(ts.Add(5*time.Hour + 30*time.Minute))
+
+	c.Assert(err, IsNil)
+	c.Assert(state.Time, Equals, ts.Add(5*time.Hour))
+
+	_, err = store.StateAt(ts.Add(-time.Minute))
+	c.Assert(err, Equals, ErrSnapshotNotFound)
+
+	snapshots, err := store.Range(ts.Add(time.Hour), ts.Add(3*time.Hour))
+
+	c.Assert(err, IsNil)
+	c.Assert(snapshots, HasLen, 3)
+	c.Assert(snapshots[0].Time, Equals, ts.Add(time.Hour))
+	c.Assert(snapshots[2].Time, Equals, ts.Add(3*time.Hour))
+
+	_, err = store.Range(ts, ts.Add(-time.Hour))
+	c.Assert(err, Equals, ErrInvalidRange)
+
+	c.Assert(store.Prune(time.Date(2025, 3, 1, 23, 0, 0, 0, time.UTC)), IsNil)
+
+	snapshots, err = store.Range(ts, ts.Add(24*time.Hour))
+
+	c.Assert(err, IsNil)
+	c.Assert(snapshots, HasLen, 7)
+
+	c.Assert(store.Prune(time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)), IsNil)
+
+	snapshots, err = store.Range(ts, ts.Add(24*time.Hour))
+
+	c.Assert(err, IsNil)
+	c.Assert(snapshots, HasLen, 5)
+
+	c.Assert(store.Close(), IsNil)
+	c.Assert(store.Save(&Snapshot{Time: ts}), Equals, ErrStoreClosed)
+	c.Assert(store.Prune(ts), Equals, ErrStoreClosed)
+
+	_, err = store.StateAt(ts)
+	c.Assert(err, Equals, ErrStoreClosed)
+	_, err = store.Range(ts, ts)
+	c.Assert(err, Equals, ErrStoreClosed)
+}
+
+func (s *HistorySuite) TestFileStoreErrors(c *C) {
+	_, err := OpenFileStore("")
+	c.Assert(err, Equals, ErrEmptyDir)
+
+	_, err = OpenFileStore("/dev/null/history")
+	c.Assert(err, ErrorMatches, `Can't create store directory: .*`)
+
+	dir := c.MkDir()
+	store, _ := OpenFileStore(dir)
+
+	os.Mkdir(filepath.Join(dir, "2025-03-01.jsonl"), 0750)
+
+	c.Assert(store.Save(&Snapshot{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}), ErrorMatches, `Can't open segment: .*`)
+
+	os.Remove(filepath.Join(dir, "2025-03-01.jsonl"))
+	os.RemoveAll(dir)
+
+	_, err = store.StateAt(time.Now())
+	c.Assert(err, ErrorMatches, `Can't read store directory: .*`)
+	_, err = store.Range(time.Now(), time.Now())
+	c.Assert(err, ErrorMatches, `Can't read store directory: .*`)
+	c.Assert(store.Prune(time.Now()), ErrorMatches, `Can't read store directory: .*`)
+
+	var nilStore *FileStore
+
+	c.Assert(nilStore.Save(nil), Equals, ErrNilStore)
+	c.Assert(nilStore.Prune(time.Now()), Equals, ErrNilStore)
+	c.Assert(nilStore.Close(), Equals, ErrNilStore)
+
+	_, err = nilStore.StateAt(time.Now())
+	c.Assert(err, Equals, ErrNilStore)
+	_, err = nilStore.Range(time.Now(), time.Now())
+	c.Assert(err, Equals, ErrNilStore)
+}
@@ -0,0 +1,221 @@
+// Package history provides local storage for historical snapshots of updown.io
+// checks and metrics
+package history
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/essentialkaos/updown"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Store is storage for checks snapshots
+type Store interface {
+	// Save saves snapshot to the store
+	Save(s *Snapshot) error
+
+	// StateAt returns the latest snapshot taken at or before given time
+	StateAt(t time.Time) (*Snapshot, error)
+
+	// Range returns snapshots taken within given period sorted by time
+	Range(from, to time.Time) ([]*Snapshot, error)
+
+	// Close closes the store
+	Close() error
+}
+
+// Snapshot contains state of checks at specific time
+type Snapshot struct {
+	Time    time.Time                  `json:"time"`
+	Checks  updown.Checks              `json:"checks"`
+	Metrics map[string]*updown.Metrics `json:"metrics,omitempty"`
+}
+
+// Trend contains history of check state
+type Trend []*TrendPoint
+
+// TrendPoint contains check state at specific time
+type TrendPoint struct {
+	Time         time.Time
+	Uptime       float64       // Check uptime reported by updown
+	IsDown       bool          // Check was down at snapshot time
+	Apdex        float64       // Apdex from snapshot metrics (-1 if there are no metrics)
+	ResponseTime time.Duration // Average response time from snapshot metrics
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilClient        = errors.New("Client is nil")
+	ErrNilStore         = errors.New("Store is nil")
+	ErrEmptySnapshot    = errors.New("Snapshot is nil or has no time")
+	ErrEmptyToken       = errors.New("Token is empty")
+	ErrInvalidRange     = errors.New("End of range is before start")
+	ErrStoreClosed      = errors.New("Store is closed")
+	ErrSnapshotNotFound = errors.New("There is no snapshot for given time")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Record fetches checks and saves snapshot to the store. If metrics period is
+// greater than zero, metrics for this period are fetched for every enabled check
+// (note that it requires one API request per check).
+func Record(client *updown.Client, store Store, metricsPeriod time.Duration) (*Snapshot, error) {
+	switch {
+	case client == nil:
+		return nil, ErrNilClient
+	case store == nil:
+		return nil, ErrNilStore
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	checks, err := client.GetChecks()
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't fetch checks: %w", err)
+	}
+
+	s := &Snapshot{Time: now, Checks: checks}
+
+	if metricsPeriod > 0 {
+		s.Metrics = map[string]*updown.Metrics{}
+
+		for _, c := range checks {
+			if c == nil || !c.IsEnabled {
+				continue
+			}
+
+			s.Metrics[c.Token], err = client.GetMetrics(c.Token, updown.MetricsOptions{
+				From: now.Add(-metricsPeriod), To: now,
+			})
+
+			if err != nil {
+				return nil, fmt.Errorf("Can't fetch metrics for check %q: %w", c.Token, err)
+			}
+		}
+	}
+
+	err = store.Save(s)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't save snapshot: %w", err)
+	}
+
+	return s, nil
+}
+
+// GetTrend returns trend of check with given token or alias within given period
+func GetTrend(store Store, tokenOrAlias string, from, to time.Time) (Trend, error) {
+	switch {
+	case store == nil:
+		return nil, ErrNilStore
+	case tokenOrAlias == "":
+		return nil, ErrEmptyToken
+	}
+
+	snapshots, err := store.Range(from, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result Trend
+
+	for _, s := range snapshots {
+		if p := s.point(tokenOrAlias); p != nil {
+			result = append(result, p)
+		}
+	}
+
+	return result, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Check returns check with given token or alias from snapshot
+func (s *Snapshot) Check(tokenOrAlias string) *updown.Check {
+	if s == nil {
+		return nil
+	}
+
+	return s.Checks.Get(tokenOrAlias)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Availability returns percentage of points when check was up
+func (t Trend) Availability() float64 {
+	if len(t) == 0 {
+		return 0
+	}
+
+	var up int
+
+	for _, p := range t {
+		if !p.IsDown {
+			up++
+		}
+	}
+
+	return float64(up) * 100 / float64(len(t))
+}
+
+// UptimeChange returns difference between the last and the first uptime values
+func (t Trend) UptimeChange() float64 {
+	if len(t) < 2 {
+		return 0
+	}
+
+	return t[len(t)-1].Uptime - t[0].Uptime
+}
+
+// MinUptime returns point with the lowest uptime
+func (t Trend) MinUptime() *TrendPoint {
+	var result *TrendPoint
+
+	for _, p := range t {
+		if result == nil || p.Uptime < result.Uptime {
+			result = p
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// point creates trend point for check with given token or alias
+func (s *Snapshot) point(tokenOrAlias string) *TrendPoint {
+	c := s.Check(tokenOrAlias)
+
+	if c == nil {
+		return nil
+	}
+
+	p := &TrendPoint{Time: s.Time, Uptime: c.Uptime, IsDown: c.IsDown, Apdex: -1}
+	m := s.Metrics[c.Token]
+
+	if m == nil {
+		m = c.Metrics
+	}
+
+	if m != nil {
+		p.Apdex = m.Apdex
+
+		if m.Timings != nil {
+			p.ResponseTime = time.Duration(m.Timings.Total) * time.Millisecond
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,168 @@
+package history
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/updown"
+	"github.com/essentialkaos/updown/updowntest"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type HistorySuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&HistorySuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *HistorySuite) TestRecord(c *C) {
+	store, err := OpenFileStore(c.MkDir())
+	c.Assert(err, IsNil)
+
+	srv := updowntest.NewServer()
+	defer srv.Close()
+
+	srv.AddCheck(&updown.Check{Token: "api1", Alias: "API", URL: "https://api.example.com", IsEnabled: true, Uptime: 99.9})
+	srv.AddCheck(&updown.Check{Token: "off1", URL: "https://off.example.com"})
+	srv.SetMetrics("api1", &updown.Metrics{Apdex: 0.95, Timings: &updown.TimingStats{Total: 250}})
+
+	_, err = Record(nil, store, 0)
+	c.Assert(err, Equals, ErrNilClient)
+	_, err = Record(srv.Client(), nil, 0)
+	c.Assert(err, Equals, ErrNilStore)
+
+	snapshot, err := Record(srv.Client(), store, time.Hour)
+
+	c.Assert(err, IsNil)
+	c.Assert(snapshot.Checks, HasLen, 2)
+	c.Assert(snapshot.Metrics, HasLen, 1)
+	c.Assert(snapshot.Metrics["api1"].Apdex, Equals, 0.95)
+
+	state, err := store.StateAt(time.Now())
+
+	c.Assert(err, IsNil)
+	c.Assert(state.Time.Equal(snapshot.Time), Equals, true)
+	c.Assert(state.Check("API").Uptime, Equals, 99.9)
+
+	trend, err := GetTrend(store, "api", snapshot.Time.Add(-time.Hour), snapshot.Time)
+
+	c.Assert(err, IsNil)
+	c.Assert(trend, HasLen, 1)
+	c.Assert(trend[0].Apdex, Equals, 0.95)
+	c.Assert(trend[0].ResponseTime, Equals, 250*time.Millisecond)
+
+	srv.InjectError("GET /checks/:token/metrics", 500)
+	_, err = Record(srv.Client(), store, time.Hour)
+	c.Assert(err, ErrorMatches, `Can't fetch metrics for check "api1": .*`)
+
+	srv.InjectError("*", 500)
+	_, err = Record(srv.Client(), store, 0)
+	c.Assert(err, ErrorMatches, `Can't fetch checks: .*`)
+
+	srv.ClearErrors()
+	store.Close()
+
+	_, err = Record(srv.Client(), store, 0)
+	c.Assert(err, ErrorMatches, `Can't save snapshot: Store is closed`)
+}
+
+func (s *HistorySuite) TestTrend(c *C) {
+	ts := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	store := &memoryStore{}
+
+	for i, uptime := range []float64{99.9, 99.5, 99.7} {
+		store.Save(&Snapshot{
+			Time: ts.Add(time.Duration(i) * time.Hour),
+			Checks: updown.Checks{
+				{Token: "a001", Uptime: uptime, IsDown: i == 1, Metrics: &updown.Metrics{Apdex: 0.8}},
+			},
+		})
+	}
+
+	store.Save(&Snapshot{Time: ts.Add(3 * time.Hour)})
+
+	trend, err := GetTrend(store, "a001", ts, ts.Add(24*time.Hour))
+
+	c.Assert(err, IsNil)
+	c.Assert(trend, HasLen, 3)
+	c.Assert(trend[0].Apdex, Equals, 0.8)
+	c.Assert(trend.Availability(), Equals, 200.0/3)
+	c.Assert(trend.UptimeChange() < -0.19, Equals, true)
+	c.Assert(trend.MinUptime().Time, Equals, ts.Add(time.Hour))
+
+	trend, err = GetTrend(store, "unknown", ts, ts.Add(24*time.Hour))
+
+	c.Assert(err, IsNil)
+	c.Assert(trend, HasLen, 0)
+	c.Assert(trend.Availability(), Equals, 0.0)
+	c.Assert(trend.UptimeChange(), Equals, 0.0)
+	c.Assert(trend.MinUptime(), IsNil)
+
+	_, err = GetTrend(nil, "a001", ts, ts)
+	c.Assert(err, Equals, ErrNilStore)
+	_, err = GetTrend(store, "", ts, ts)
+	c.Assert(err, Equals, ErrEmptyToken)
+	_, err = GetTrend(store, "a001", ts, ts.Add(-time.Hour))
+	c.Assert(err, Equals, ErrInvalidRange)
+
+	var snapshot *Snapshot
+
+	c.Assert(snapshot.Check("a001"), IsNil)
+	c.Assert((&Snapshot{}).point("a001"), IsNil)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// memoryStore is simple in-memory store used for testing Store consumers
+type memoryStore struct {
+	snapshots []*Snapshot
+}
+
+func (s *memoryStore) Save(snapshot *Snapshot) error {
+	s.snapshots = append(s.snapshots, snapshot)
+	return nil
+}
+
+func (s *memoryStore) StateAt(t time.Time) (*Snapshot, error) {
+	for i := len(s.snapshots) - 1; i >= 0; i-- {
+		if !s.snapshots[i].Time.After(t) {
+			return s.snapshots[i], nil
+		}
+	}
+
+	return nil, ErrSnapshotNotFound
+}
+
+func (s *memoryStore) Range(from, to time.Time) ([]*Snapshot, error) {
+	if to.Before(from) {
+		return nil, ErrInvalidRange
+	}
+
+	var result []*Snapshot
+
+	for _, snapshot := range s.snapshots {
+		if !snapshot.Time.Before(from) && !snapshot.Time.After(to) {
+			result = append(result, snapshot)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,227 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Maintenance contains info about maintenance window
+type Maintenance struct {
+	StartedAt time.Time           `json:"started_at"`
+	Until     time.Time           `json:"until"`
+	Checks    []*MaintenanceCheck `json:"checks"`
+}
+
+// MaintenanceCheck contains state of check before maintenance
+type MaintenanceCheck struct {
+	Token     string `json:"token"`
+	Alias     string `json:"alias,omitempty"`
+	MuteUntil Date   `json:"mute_until"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrEmptyStateFile      = errors.New("Maintenance state file path is empty")
+	ErrNoChecks            = errors.New("There are no checks for maintenance")
+	ErrInvalidDuration     = errors.New("Maintenance duration must be greater than zero")
+	ErrMaintenanceActive   = errors.New("Maintenance is already in progress")
+	ErrMaintenanceNotFound = errors.New("There is no maintenance in progress")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ReadMaintenance reads maintenance state from file
+func ReadMaintenance(file string) (*Maintenance, error) {
+	if file == "" {
+		return nil, ErrEmptyStateFile
+	}
+
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrMaintenanceNotFound
+		}
+
+		return nil, fmt.Errorf("Can't read maintenance state: %w", err)
+	}
+
+	m := &Maintenance{}
+	err = json.Unmarshal(data, m)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode maintenance state: %w", err)
+	}
+
+	return m, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// StartMaintenance mutes given checks for given duration. Previous state of
+// checks is saved to state file before muting, so it can be restored using
+// FinishMaintenance even if the process was restarted.
+//
+// Checks can be selected using Checks.Select or Checks.Filter.
+func (c *Client) StartMaintenance(file string, duration time.Duration, checks Checks) (*Maintenance, error) {
+	switch {
+	case c == nil || c.engine == nil:
+		return nil, ErrNilClient
+	case file == "":
+		return nil, ErrEmptyStateFile
+	case duration <= 0:
+		return nil, ErrInvalidDuration
+	}
+
+	_, err := os.Stat(file)
+
+	if err == nil {
+		return nil, ErrMaintenanceActive
+	}
+
+	now := time.Now()
+	m := &Maintenance{
+		StartedAt: now.UTC().Truncate(time.Second),
+		Until:     now.Add(duration).UTC().Truncate(time.Second),
+	}
+
+	for _, cc := range checks {
+		if cc != nil && cc.Token != "" {
+			m.Checks = append(m.Checks, &MaintenanceCheck{
+				Token:     cc.Token,
+				Alias:     cc.Alias,
+				MuteUntil: cc.MuteUntil,
+			})
+		}
+	}
+
+	if len(m.Checks) == 0 {
+		return nil, ErrNoChecks
+	}
+
+	err = m.save(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+
+	for _, mc := range m.Checks {
+		// Don't shorten existing mute
+		if mc.MuteUntil.After(m.Until) {
+			continue
+		}
+
+		_, err = c.MuteCheck(mc.Token, m.Until)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Can't mute check %s: %w", mc.Token, err))
+		}
+	}
+
+	return m, errors.Join(errs...)
+}
+
+// FinishMaintenance restores state of checks saved by StartMaintenance and
+// removes state file. If state of some checks can't be restored, they are kept
+// in the state file, so FinishMaintenance can be called again.
+func (c *Client) FinishMaintenance(file string) error {
+	if c == nil || c.engine == nil {
+		return ErrNilClient
+	}
+
+	m, err := ReadMaintenance(file)
+
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	var failed []*MaintenanceCheck
+
+	now := time.Now()
+
+	for _, mc := range m.Checks {
+		if mc.MuteUntil.After(now) {
+			_, err = c.MuteCheck(mc.Token, mc.MuteUntil.Time)
+		} else {
+			_, err = c.UnmuteCheck(mc.Token)
+		}
+
+		if err != nil {
+			failed = append(failed, mc)
+			errs = append(errs, fmt.Errorf("Can't restore check %s: %w", mc.Token, err))
+		}
+	}
+
+	if len(failed) != 0 {
+		m.Checks = failed
+		return errors.Join(append(errs, m.save(file))...)
+	}
+
+	err = os.Remove(file)
+
+	if err != nil {
+		return fmt.Errorf("Can't remove maintenance state: %w", err)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsExpired returns true if maintenance window is over
+func (m *Maintenance) IsExpired() bool {
+	return m == nil || time.Now().After(m.Until)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// save atomically saves maintenance state to file
+func (m *Maintenance) save(file string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("Can't encode maintenance state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".maintenance-*")
+
+	if err != nil {
+		return fmt.Errorf("Can't save maintenance state: %w", err)
+	}
+
+	_, err = tmp.Write(data)
+
+	if err == nil {
+		err = tmp.Sync()
+	}
+
+	tmp.Close()
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), file)
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("Can't save maintenance state: %w", err)
+	}
+
+	return nil
+}
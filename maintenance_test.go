@@ -0,0 +1,129 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"time"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestMuteEnable(c *C) {
+	api, err := NewClient("test1234")
+	c.Assert(err, IsNil)
+
+	until := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	check, err := api.MuteCheck("ngg8", until)
+	c.Assert(err, IsNil)
+	c.Assert(check.MuteUntil.Equal(until), Equals, true)
+
+	check, err = api.UnmuteCheck("ngg8")
+	c.Assert(err, IsNil)
+	c.Assert(check.MuteUntil.IsZero(), Equals, true)
+
+	check, err = api.DisableCheck("ngg8")
+	c.Assert(err, IsNil)
+	c.Assert(check.IsEnabled, Equals, false)
+
+	check, err = api.EnableCheck("ngg8")
+	c.Assert(err, IsNil)
+	c.Assert(check.IsEnabled, Equals, true)
+
+	_, err = api.MuteCheck("ngg8", time.Time{})
+	c.Assert(err, Equals, ErrEmptyMuteDate)
+
+	_, err = api.MuteCheck("", until)
+	c.Assert(err, Equals, ErrEmptyToken)
+}
+
+func (s *UpdownSuite) TestMaintenance(c *C) {
+	api, err := NewClient("test1234")
+	c.Assert(err, IsNil)
+
+	file := c.MkDir() + "/maintenance.json"
+	checks := Checks{
+		{Token: "ngg8", Alias: "Updown"},
+		{Token: "abcd", Alias: "Unknown", MuteUntil: Date{time.Now().Add(24 * time.Hour)}},
+		{Token: "xyz1", Alias: "Muted", MuteUntil: Date{time.Now().Add(time.Hour)}},
+	}
+
+	m, err := api.StartMaintenance(file, time.Hour, checks.Select("updown", "abcd"))
+	c.Assert(err, IsNil)
+	c.Assert(m.Checks, HasLen, 2)
+	c.Assert(m.IsExpired(), Equals, false)
+
+	_, err = api.StartMaintenance(file, time.Hour, checks)
+	c.Assert(err, Equals, ErrMaintenanceActive)
+
+	m, err = ReadMaintenance(file)
+	c.Assert(err, IsNil)
+	c.Assert(m.Checks, HasLen, 2)
+	c.Assert(m.Checks[0].Token, Equals, "ngg8")
+	c.Assert(m.Checks[0].MuteUntil.IsZero(), Equals, true)
+	c.Assert(m.Checks[1].Token, Equals, "abcd")
+	c.Assert(m.Checks[1].MuteUntil.IsZero(), Equals, false)
+
+	err = api.FinishMaintenance(file)
+	c.Assert(err, ErrorMatches, "Can't restore check abcd: .*")
+
+	m, err = ReadMaintenance(file)
+	c.Assert(err, IsNil)
+	c.Assert(m.Checks, HasLen, 1)
+	c.Assert(m.Checks[0].Token, Equals, "abcd")
+
+	m.Checks[0].Token = "ngg8"
+	c.Assert(m.save(file), IsNil)
+
+	c.Assert(api.FinishMaintenance(file), IsNil)
+	c.Assert(api.FinishMaintenance(file), Equals, ErrMaintenanceNotFound)
+
+	_, err = api.StartMaintenance(file, 2*time.Hour, checks.Select("xyz1"))
+	c.Assert(err, ErrorMatches, "Can't mute check xyz1: .*")
+	c.Assert(api.FinishMaintenance(file), NotNil)
+
+	os.Remove(file)
+
+	_, err = api.StartMaintenance(file, time.Minute, checks.Select("abcd"))
+	c.Assert(err, IsNil)
+}
+
+func (s *UpdownSuite) TestMaintenanceErrors(c *C) {
+	var nc *Client
+
+	_, err := nc.StartMaintenance("file.json", time.Hour, nil)
+	c.Assert(err, Equals, ErrNilClient)
+	c.Assert(nc.FinishMaintenance("file.json"), Equals, ErrNilClient)
+
+	api, _ := NewClient("test1234")
+	dir := c.MkDir()
+
+	_, err = api.StartMaintenance("", time.Hour, nil)
+	c.Assert(err, Equals, ErrEmptyStateFile)
+	_, err = api.StartMaintenance(dir+"/file.json", 0, nil)
+	c.Assert(err, Equals, ErrInvalidDuration)
+	_, err = api.StartMaintenance(dir+"/file.json", time.Hour, Checks{nil})
+	c.Assert(err, Equals, ErrNoChecks)
+	_, err = api.StartMaintenance(dir+"/unknown/file.json", time.Hour, Checks{{Token: "ngg8"}})
+	c.Assert(err, ErrorMatches, "Can't save maintenance state: .*")
+
+	_, err = ReadMaintenance("")
+	c.Assert(err, Equals, ErrEmptyStateFile)
+	_, err = ReadMaintenance(dir)
+	c.Assert(err, ErrorMatches, "Can't read maintenance state: .*")
+
+	os.WriteFile(dir+"/broken.json", []byte("{"), 0644)
+	_, err = ReadMaintenance(dir + "/broken.json")
+	c.Assert(err, ErrorMatches, "Can't decode maintenance state: .*")
+
+	var m *Maintenance
+	c.Assert(m.IsExpired(), Equals, true)
+}
@@ -0,0 +1,290 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Percentiles contains estimated response time percentiles
+type Percentiles struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// responseTimeBounds contains upper bounds of response time buckets
+var responseTimeBounds = [9]time.Duration{
+	125 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	4 * time.Second,
+	8 * time.Second,
+	16 * time.Second,
+	32 * time.Second,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// MergeMetrics merges metrics from multiple checks or time buckets into one.
+// Requests statistics are summed, uptime and timings are weighted by the number
+// of samples. If all metrics have requests statistics, Apdex is recalculated
+// using summed requests statistics. If any of metrics has no info about samples,
+// all metrics have equal weights and Apdex is an average of Apdex values.
+//
+// If there is only one metrics, its copy will be returned.
+func MergeMetrics(metrics ...*Metrics) *Metrics {
+	return mergeMetrics(metrics)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Merge merges metrics for all hours into one
+func (s MetricsSeries) Merge() *Metrics {
+	metrics := make([]*Metrics, 0, len(s))
+
+	for _, p := range s {
+		metrics = append(metrics, p.Metrics)
+	}
+
+	return mergeMetrics(metrics)
+}
+
+// Merge merges metrics from all monitoring nodes into one
+func (m HostsMetrics) Merge() *Metrics {
+	metrics := make([]*Metrics, 0, len(m))
+
+	for _, mm := range m {
+		metrics = append(metrics, mm)
+	}
+
+	return mergeMetrics(metrics)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Percentile returns estimated response time percentile (0-100)
+func (m *Metrics) Percentile(p float64) time.Duration {
+	if m == nil || m.Requests == nil {
+		return 0
+	}
+
+	return m.Requests.ByResponseTime.Percentile(p)
+}
+
+// Percentiles returns estimated p50, p90 and p99 response time percentiles
+func (m *Metrics) Percentiles() Percentiles {
+	return Percentiles{
+		P50: m.Percentile(50),
+		P90: m.Percentile(90),
+		P99: m.Percentile(99),
+	}
+}
+
+// Apdex calculates Apdex score using numbers of satisfied and tolerated requests
+func (r *RequestStats) Apdex() float64 {
+	if r == nil || r.Samples <= 0 {
+		return 0
+	}
+
+	return (float64(r.Satisfied) + float64(r.Tolerated)/2) / float64(r.Samples)
+}
+
+// Percentile returns estimated response time percentile (0-100). Value is linearly
+// interpolated within the bucket which contains the percentile.
+func (s *ResponseTimeStats) Percentile(p float64) time.Duration {
+	if s == nil {
+		return 0
+	}
+
+	buckets := s.buckets()
+	total := buckets[len(buckets)-1]
+
+	if total <= 0 {
+		return 0
+	}
+
+	p = min(max(p, 0), 100)
+	target := p / 100 * float64(total)
+
+	var prevCount int
+	var prevBound time.Duration
+
+	for i, count := range buckets {
+		if float64(count) >= target {
+			if count == prevCount {
+				return prevBound
+			}
+
+			ratio := (target - float64(prevCount)) / float64(count-prevCount)
+			return prevBound + time.Duration(ratio*float64(responseTimeBounds[i]-prevBound))
+		}
+
+		prevCount, prevBound = count, responseTimeBounds[i]
+	}
+
+	return responseTimeBounds[len(responseTimeBounds)-1]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// buckets returns cumulative bucket counters. Buckets which are not present in
+// API response get counter from the previous bucket.
+func (s *ResponseTimeStats) buckets() [9]int {
+	buckets := [9]int{
+		s.Under125, s.Under250, s.Under500,
+		s.Under1k, s.Under2k, s.Under4k,
+		s.Under8k, s.Under16k, s.Under32k,
+	}
+
+	for i := 1; i < len(buckets); i++ {
+		buckets[i] = max(buckets[i], buckets[i-1])
+	}
+
+	return buckets
+}
+
+// add adds counters from given stats
+func (s *ResponseTimeStats) add(stats *ResponseTimeStats) {
+	if stats == nil {
+		return
+	}
+
+	b := stats.buckets()
+
+	s.Under125 += b[0]
+	s.Under250 += b[1]
+	s.Under500 += b[2]
+	s.Under1k += b[3]
+	s.Under2k += b[4]
+	s.Under4k += b[5]
+	s.Under8k += b[6]
+	s.Under16k += b[7]
+	s.Under32k += b[8]
+}
+
+// clone returns deep copy of metrics
+func (m *Metrics) clone() *Metrics {
+	result := *m
+
+	if m.Timings != nil {
+		timings := *m.Timings
+		result.Timings = &timings
+	}
+
+	if m.Requests != nil {
+		requests := *m.Requests
+		result.Requests = &requests
+
+		if m.Requests.ByResponseTime != nil {
+			stats := *m.Requests.ByResponseTime
+			requests.ByResponseTime = &stats
+		}
+	}
+
+	return &result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// mergeMetrics merges multiple metrics into one
+func mergeMetrics(metrics []*Metrics) *Metrics {
+	var items []*Metrics
+
+	for _, m := range metrics {
+		if m != nil {
+			items = append(items, m)
+		}
+	}
+
+	switch len(items) {
+	case 0:
+		return nil
+	case 1:
+		return items[0].clone()
+	}
+
+	// Weights must be consistent for all metrics, so if we don't know number
+	// of samples for some of them, we use equal weights
+	withSamples := !slices.ContainsFunc(items, func(m *Metrics) bool {
+		return m.Requests == nil || m.Requests.Samples <= 0
+	})
+
+	// Apdex is recalculated only if all metrics have counters, mixing of
+	// recalculated and averaged values gives inconsistent results
+	withCounters := withSamples && !slices.ContainsFunc(items, func(m *Metrics) bool {
+		return m.Apdex != 0 && m.Requests.Satisfied+m.Requests.Tolerated == 0
+	})
+
+	var uptime, apdex float64
+	var weightSum, timingsWeight int
+
+	result := &Metrics{}
+	timings := &TimingStats{}
+	requests := &RequestStats{ByResponseTime: &ResponseTimeStats{}}
+
+	for _, m := range items {
+		weight := 1
+
+		if withSamples {
+			weight = m.Requests.Samples
+		}
+
+		if m.Requests != nil {
+			requests.Samples += m.Requests.Samples
+			requests.Failures += m.Requests.Failures
+			requests.Satisfied += m.Requests.Satisfied
+			requests.Tolerated += m.Requests.Tolerated
+			requests.ByResponseTime.add(m.Requests.ByResponseTime)
+		}
+
+		uptime += m.Uptime * float64(weight)
+		apdex += m.Apdex * float64(weight)
+		weightSum += weight
+
+		if m.Timings != nil {
+			timings.Redirect += m.Timings.Redirect * weight
+			timings.NameLookup += m.Timings.NameLookup * weight
+			timings.Connection += m.Timings.Connection * weight
+			timings.Handshake += m.Timings.Handshake * weight
+			timings.Response += m.Timings.Response * weight
+			timings.Total += m.Timings.Total * weight
+			timingsWeight += weight
+		}
+	}
+
+	result.Uptime = uptime / float64(weightSum)
+	result.Apdex = apdex / float64(weightSum)
+
+	if timingsWeight > 0 {
+		timings.Redirect /= timingsWeight
+		timings.NameLookup /= timingsWeight
+		timings.Connection /= timingsWeight
+		timings.Handshake /= timingsWeight
+		timings.Response /= timingsWeight
+		timings.Total /= timingsWeight
+		result.Timings = timings
+	}
+
+	if withCounters {
+		result.Apdex = requests.Apdex()
+	}
+
+	if requests.Samples > 0 {
+		result.Requests = requests
+	}
+
+	return result
+}
@@ -0,0 +1,108 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"time"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestMetricsMergeHelpers(c *C) {
+	c.Assert(MergeMetrics(), IsNil)
+	c.Assert(MergeMetrics(nil, nil), IsNil)
+
+	m1 := &Metrics{
+		Uptime:  100,
+		Apdex:   1,
+		Timings: &TimingStats{Total: 100},
+		Requests: &RequestStats{
+			Samples: 300, Satisfied: 300,
+			ByResponseTime: &ResponseTimeStats{Under125: 100, Under250: 300},
+		},
+	}
+
+	m2 := &Metrics{
+		Uptime:  96,
+		Apdex:   0.5,
+		Timings: &TimingStats{Total: 500},
+		Requests: &RequestStats{
+			Samples: 100, Failures: 4, Satisfied: 40, Tolerated: 20,
+			ByResponseTime: &ResponseTimeStats{Under250: 40, Under500: 60, Under1k: 96},
+		},
+	}
+
+	mc := MergeMetrics(m1)
+
+	c.Assert(mc, DeepEquals, m1)
+	c.Assert(mc == m1, Equals, false)
+	c.Assert(mc.Requests == m1.Requests, Equals, false)
+	c.Assert(mc.Requests.ByResponseTime == m1.Requests.ByResponseTime, Equals, false)
+	c.Assert(mc.Timings == m1.Timings, Equals, false)
+
+	m := MergeMetrics(m1, m2, nil)
+
+	c.Assert(m, NotNil)
+	c.Assert(m.Uptime, Equals, 99.0)
+	c.Assert(m.Apdex, Equals, 0.875)
+	c.Assert(m.Timings.Total, Equals, 200)
+	c.Assert(m.Requests.Samples, Equals, 400)
+	c.Assert(m.Requests.Failures, Equals, 4)
+	c.Assert(m.Requests.ByResponseTime.Under125, Equals, 100)
+	c.Assert(m.Requests.ByResponseTime.Under250, Equals, 340)
+	c.Assert(m.Requests.ByResponseTime.Under500, Equals, 360)
+	c.Assert(m.Requests.ByResponseTime.Under32k, Equals, 396)
+
+	series := MetricsSeries{{Metrics: m1}, {Metrics: m2}}
+	c.Assert(series.Merge().Requests.Samples, Equals, 400)
+
+	hosts := HostsMetrics{"fra": m1, "syd": m2}
+	c.Assert(hosts.Merge().Requests.Samples, Equals, 400)
+}
+
+func (s *UpdownSuite) TestMetricsApdex(c *C) {
+	var r *RequestStats
+
+	c.Assert(r.Apdex(), Equals, 0.0)
+	c.Assert((&RequestStats{}).Apdex(), Equals, 0.0)
+
+	r = &RequestStats{Samples: 87441, Satisfied: 87357, Tolerated: 77}
+
+	c.Assert(r.Apdex() > 0.999 && r.Apdex() < 1, Equals, true)
+}
+
+func (s *UpdownSuite) TestMetricsPercentiles(c *C) {
+	var m *Metrics
+	var rt *ResponseTimeStats
+
+	c.Assert(m.Percentile(50), Equals, time.Duration(0))
+	c.Assert((&Metrics{}).Percentile(50), Equals, time.Duration(0))
+	c.Assert(rt.Percentile(50), Equals, time.Duration(0))
+	c.Assert((&ResponseTimeStats{}).Percentile(50), Equals, time.Duration(0))
+
+	rt = &ResponseTimeStats{Under125: 50, Under250: 50, Under500: 90, Under1k: 100}
+
+	c.Assert(rt.Percentile(0), Equals, time.Duration(0))
+	c.Assert(rt.Percentile(-10), Equals, time.Duration(0))
+	c.Assert(rt.Percentile(25), Equals, 62500*time.Microsecond)
+	c.Assert(rt.Percentile(50), Equals, 125*time.Millisecond)
+	c.Assert(rt.Percentile(70), Equals, 375*time.Millisecond)
+	c.Assert(rt.Percentile(95), Equals, 750*time.Millisecond)
+	c.Assert(rt.Percentile(100), Equals, time.Second)
+	c.Assert(rt.Percentile(200), Equals, time.Second)
+
+	m = &Metrics{Requests: &RequestStats{ByResponseTime: rt}}
+
+	c.Assert(m.Percentiles(), DeepEquals, Percentiles{
+		P50: 125 * time.Millisecond,
+		P90: 500 * time.Millisecond,
+		P99: 950 * time.Millisecond,
+	})
+}
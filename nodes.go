@@ -0,0 +1,282 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	CONTINENT_AFRICA        = "Africa"
+	CONTINENT_ANTARCTICA    = "Antarctica"
+	CONTINENT_ASIA          = "Asia"
+	CONTINENT_EUROPE        = "Europe"
+	CONTINENT_NORTH_AMERICA = "North America"
+	CONTINENT_OCEANIA       = "Oceania"
+	CONTINENT_SOUTH_AMERICA = "South America"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Coverage contains info about geographical coverage of check
+type Coverage struct {
+	Total  int     // Total number of nodes
+	Active int     // Number of active (not disabled) nodes
+	Ratio  float64 // Ratio of active nodes (0-1)
+
+	Countries  []string // Country codes of active nodes
+	Continents []string // Continents of active nodes
+
+	// MissingContinents contains continents which have nodes, but all of
+	// them are disabled
+	MissingContinents []string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// continents is map country code → continent
+var continents = map[string]string{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func init() {
+	for continent, codes := range map[string]string{
+		CONTINENT_AFRICA: "ao bf bi bj bw cd cf cg ci cm cv dj dz eg eh er et ga gh gm gn gq " +
+			"gw ke km lr ls ly ma mg ml mr mu mw mz na ne ng re rw sc sd sh sl sn so ss st " +
+			"sz td tg tn tz ug yt za zm zw",
+		CONTINENT_ANTARCTICA: "aq bv gs hm tf",
+		CONTINENT_ASIA: "ae af am az bd bh bn bt cc cn cx ge hk id il in io iq ir jo jp kg " +
+			"kh kp kr kw kz la lb lk mm mn mo mv my np om ph pk ps qa sa sg sy th tj tl tm " +
+			"tr tw uz vn ye",
+		CONTINENT_EUROPE: "ad al at ax ba be bg by ch cy cz de dk ee es fi fo fr gb gg gi gr " +
+			"hr hu ie im is it je li lt lu lv mc md me mk mt nl no pl pt ro rs ru se si sj " +
+			"sk sm ua uk va xk",
+		CONTINENT_NORTH_AMERICA: "ag ai aw bb bl bm bq bs bz ca cr cu cw dm do gd gl gp gt hn " +
+			"ht jm kn ky lc mf mq ms mx ni pa pm pr sv sx tc tt um us vc vg vi",
+		CONTINENT_OCEANIA: "as au ck fj fm gu ki mh mp nc nf nr nu nz pf pg pn pw sb tk to " +
+			"tv vu wf ws",
+		CONTINENT_SOUTH_AMERICA: "ar bo br cl co ec fk gf gy pe py sr uy ve",
+	} {
+		for _, code := range strings.Fields(codes) {
+			continents[code] = continent
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Continent returns name of continent where node is located
+func (n *Node) Continent() string {
+	if n == nil {
+		return ""
+	}
+
+	return continents[strings.ToLower(n.CountryCode)]
+}
+
+// Location returns node location (city and country)
+func (n *Node) Location() string {
+	switch {
+	case n == nil:
+		return ""
+	case n.City == "":
+		return n.Country
+	case n.Country == "":
+		return n.City
+	}
+
+	return n.City + ", " + n.Country
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Names returns sorted slice with nodes names
+func (n Nodes) Names() []string {
+	result := make([]string, 0, len(n))
+
+	for name := range n {
+		result = append(result, name)
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// Select returns nodes with given names
+func (n Nodes) Select(names ...string) Nodes {
+	result := Nodes{}
+
+	for _, name := range names {
+		if n[name] != nil {
+			result[name] = n[name]
+		}
+	}
+
+	return result
+}
+
+// ByCountry returns nodes grouped by country code (in upper case)
+func (n Nodes) ByCountry() map[string]Nodes {
+	return n.group(func(node *Node) string {
+		return strings.ToUpper(node.CountryCode)
+	})
+}
+
+// ByContinent returns nodes grouped by continent. Nodes with unknown country
+// code are grouped with empty key.
+func (n Nodes) ByContinent() map[string]Nodes {
+	return n.group(func(node *Node) string {
+		return node.Continent()
+	})
+}
+
+// Describe returns human-readable list of cities of nodes with given names
+// (e.g. "Sydney and Tokyo")
+func (n Nodes) Describe(names ...string) string {
+	var cities []string
+
+	for _, name := range names {
+		city := name
+
+		if n[name] != nil && n[name].City != "" {
+			city = n[name].City
+		}
+
+		if !slices.Contains(cities, city) {
+			cities = append(cities, city)
+		}
+	}
+
+	switch len(cities) {
+	case 0:
+		return ""
+	case 1:
+		return cities[0]
+	}
+
+	return strings.Join(cities[:len(cities)-1], ", ") + " and " + cities[len(cities)-1]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ActiveNodes returns nodes which are used for check (i.e. not disabled)
+func (c *Check) ActiveNodes(nodes Nodes) Nodes {
+	result := Nodes{}
+
+	for name, node := range nodes {
+		if node != nil && (c == nil || !slices.Contains(c.DisabledLocations, name)) {
+			result[name] = node
+		}
+	}
+
+	return result
+}
+
+// Coverage returns info about geographical coverage of check
+func (c *Check) Coverage(nodes Nodes) *Coverage {
+	active := c.ActiveNodes(nodes)
+	result := &Coverage{Active: len(active)}
+
+	for _, node := range nodes {
+		if node != nil {
+			result.Total++
+		}
+	}
+
+	if result.Total != 0 {
+		result.Ratio = float64(result.Active) / float64(result.Total)
+	}
+
+	for country := range active.ByCountry() {
+		if country != "" {
+			result.Countries = append(result.Countries, country)
+		}
+	}
+
+	activeContinents := active.ByContinent()
+
+	for continent := range activeContinents {
+		if continent != "" {
+			result.Continents = append(result.Continents, continent)
+		}
+	}
+
+	for continent := range nodes.ByContinent() {
+		if continent != "" && activeContinents[continent] == nil {
+			result.MissingContinents = append(result.MissingContinents, continent)
+		}
+	}
+
+	slices.Sort(result.Countries)
+	slices.Sort(result.Continents)
+	slices.Sort(result.MissingContinents)
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NodeInfo returns info about node which sent request
+func (r *DowntimeRequest) NodeInfo(nodes Nodes) *Node {
+	if r == nil {
+		return nil
+	}
+
+	return nodes[r.Node]
+}
+
+// FailedNodes returns sorted slice with names of nodes which reported failure
+// (requires detailed downtime info)
+func (d *Downtime) FailedNodes() []string {
+	var result []string
+
+	if d == nil {
+		return nil
+	}
+
+	for _, r := range d.DownResults {
+		if r == nil || r.Request == nil || r.Request.Node == "" {
+			continue
+		}
+
+		if !slices.Contains(result, r.Request.Node) {
+			result = append(result, r.Request.Node)
+		}
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// group groups nodes using given key function
+func (n Nodes) group(key func(node *Node) string) map[string]Nodes {
+	result := map[string]Nodes{}
+
+	for name, node := range n {
+		if node == nil {
+			continue
+		}
+
+		k := key(node)
+
+		if result[k] == nil {
+			result[k] = Nodes{}
+		}
+
+		result[k][name] = node
+	}
+
+	return result
+}
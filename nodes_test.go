@@ -0,0 +1,101 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var testNodes = Nodes{
+	"lan": {City: "Los Angeles", Country: "US", CountryCode: "us"},
+	"mia": {City: "Miami", Country: "US", CountryCode: "us"},
+	"fra": {City: "Frankfurt", Country: "Germany", CountryCode: "de"},
+	"syd": {City: "Sydney", Country: "Australia", CountryCode: "au"},
+	"tok": {City: "Tokyo", Country: "Japan", CountryCode: "jp"},
+	"xxx": {City: "Unknown", CountryCode: "zz"},
+	"nil": nil,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestNodeInfo(c *C) {
+	c.Assert(testNodes["fra"].Continent(), Equals, CONTINENT_EUROPE)
+	c.Assert(testNodes["syd"].Continent(), Equals, CONTINENT_OCEANIA)
+	c.Assert(testNodes["xxx"].Continent(), Equals, "")
+	c.Assert(testNodes["nil"].Continent(), Equals, "")
+
+	c.Assert(testNodes["fra"].Location(), Equals, "Frankfurt, Germany")
+	c.Assert(testNodes["xxx"].Location(), Equals, "Unknown")
+	c.Assert((&Node{Country: "France"}).Location(), Equals, "France")
+	c.Assert(testNodes["nil"].Location(), Equals, "")
+
+	c.Assert(testNodes.Names(), DeepEquals, []string{"fra", "lan", "mia", "nil", "syd", "tok", "xxx"})
+	c.Assert(testNodes.Select("fra", "nil", "abc").Names(), DeepEquals, []string{"fra"})
+
+	byCountry := testNodes.ByCountry()
+
+	c.Assert(byCountry, HasLen, 5)
+	c.Assert(byCountry["US"].Names(), DeepEquals, []string{"lan", "mia"})
+
+	byContinent := testNodes.ByContinent()
+
+	c.Assert(byContinent, HasLen, 5)
+	c.Assert(byContinent[CONTINENT_NORTH_AMERICA], HasLen, 2)
+	c.Assert(byContinent[CONTINENT_ASIA].Names(), DeepEquals, []string{"tok"})
+	c.Assert(byContinent[""].Names(), DeepEquals, []string{"xxx"})
+
+	c.Assert(testNodes.Describe(), Equals, "")
+	c.Assert(testNodes.Describe("syd"), Equals, "Sydney")
+	c.Assert(testNodes.Describe("syd", "tok"), Equals, "Sydney and Tokyo")
+	c.Assert(testNodes.Describe("syd", "tok", "abc", "syd"), Equals, "Sydney, Tokyo and abc")
+}
+
+func (s *UpdownSuite) TestCheckNodes(c *C) {
+	check := &Check{DisabledLocations: []string{"syd", "tok", "xxx"}}
+
+	c.Assert(check.ActiveNodes(testNodes).Names(), DeepEquals, []string{"fra", "lan", "mia"})
+
+	cov := check.Coverage(testNodes)
+
+	c.Assert(cov.Total, Equals, 6)
+	c.Assert(cov.Active, Equals, 3)
+	c.Assert(cov.Ratio, Equals, 0.5)
+	c.Assert(cov.Countries, DeepEquals, []string{"DE", "US"})
+	c.Assert(cov.Continents, DeepEquals, []string{CONTINENT_EUROPE, CONTINENT_NORTH_AMERICA})
+	c.Assert(cov.MissingContinents, DeepEquals, []string{CONTINENT_ASIA, CONTINENT_OCEANIA})
+
+	var nilCheck *Check
+
+	c.Assert(nilCheck.ActiveNodes(testNodes), HasLen, 6)
+	c.Assert(nilCheck.Coverage(nil).Ratio, Equals, 0.0)
+}
+
+func (s *UpdownSuite) TestDowntimeNodes(c *C) {
+	d := &Downtime{
+		DownResults: []*DowntimeCheck{
+			{Request: &DowntimeRequest{Node: "tok"}},
+			{Request: &DowntimeRequest{Node: "syd"}},
+			{Request: &DowntimeRequest{Node: "tok"}},
+			{Request: nil},
+			nil,
+		},
+	}
+
+	c.Assert(d.FailedNodes(), DeepEquals, []string{"syd", "tok"})
+	c.Assert(testNodes.Describe(d.FailedNodes()...), Equals, "Sydney and Tokyo")
+	c.Assert(d.DownResults[0].Request.NodeInfo(testNodes).City, Equals, "Tokyo")
+	c.Assert((&DowntimeRequest{Node: "abc"}).NodeInfo(testNodes), IsNil)
+
+	var nilRequest *DowntimeRequest
+	var nilDowntime *Downtime
+
+	c.Assert(nilRequest.NodeInfo(testNodes), IsNil)
+	c.Assert(nilDowntime.FailedNodes(), IsNil)
+}
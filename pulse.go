@@ -0,0 +1,255 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/req"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PULSE_STATUS_OK is status of successful pulse acknowledgement
+const PULSE_STATUS_OK = "OK"
+
+const (
+	DEFAULT_PULSE_TIMEOUT     = 10 * time.Second
+	DEFAULT_PULSE_RETRIES     = 4
+	DEFAULT_PULSE_RETRY_PAUSE = time.Second / 4
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PulseClient is client for sending pulse (cron monitoring) requests
+//
+// https://updown.io/doc/how-pulse-cron-monitoring-works
+type PulseClient struct {
+	// Timeout is timeout of every request attempt
+	Timeout time.Duration
+
+	// Retries is maximum number of retries after failed attempt
+	Retries int
+
+	// RetryPause is pause between attempts
+	RetryPause time.Duration
+
+	// HTTPClient is custom HTTP client used for requests
+	HTTPClient *http.Client
+
+	// Instrumentation is instrumentation for pulse requests. If not set,
+	// instrumentation set by SetPulseInstrumentation is used.
+	Instrumentation Instrumentation
+}
+
+// PulseResult contains pulse request acknowledgement
+type PulseResult struct {
+	UUID   string // Request UUID
+	Status string // Acknowledgement status
+	Raw    string // Raw response body
+}
+
+// PulseHandler is function which receives result of every heartbeat pulse
+type PulseHandler func(result *PulseResult, err error)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrInvalidPulseResponse = errors.New("Pulse response is not an acknowledgement")
+	ErrInvalidPulseInterval = errors.New("Heartbeat interval must be greater than zero")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// uuidRegex is regex pattern for request UUID validation
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewPulseClient creates new pulse client with default configuration
+func NewPulseClient() *PulseClient {
+	return &PulseClient{
+		Timeout:    DEFAULT_PULSE_TIMEOUT,
+		Retries:    DEFAULT_PULSE_RETRIES,
+		RetryPause: DEFAULT_PULSE_RETRY_PAUSE,
+	}
+}
+
+// ParsePulseResponse parses and validates pulse response
+func ParsePulseResponse(data string) (*PulseResult, error) {
+	status, uuid, _ := strings.Cut(strings.TrimSpace(data), " ")
+	status = strings.TrimSuffix(status, ":")
+
+	if status != PULSE_STATUS_OK || !uuidRegex.MatchString(uuid) {
+		return nil, ErrInvalidPulseResponse
+	}
+
+	return &PulseResult{UUID: uuid, Status: status, Raw: data}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Send sends pulse request with optional payload
+func (c *PulseClient) Send(url, payload string) (*PulseResult, error) {
+	return c.SendContext(context.Background(), url, payload)
+}
+
+// SendContext sends pulse request with optional payload. Context cancellation
+// stops retries, and context deadline limits timeout of every attempt.
+func (c *PulseClient) SendContext(ctx context.Context, url, payload string) (*PulseResult, error) {
+	switch {
+	case c == nil:
+		return nil, ErrNilClient
+	case url == "":
+		return nil, ErrEmptyPulseURL
+	}
+
+	r := req.Request{URL: url, Timeout: c.Timeout}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if timeout := time.Until(deadline); r.Timeout <= 0 || timeout < r.Timeout {
+			r.Timeout = max(timeout, time.Millisecond)
+		}
+	}
+
+	if payload != "" {
+		r.Method = req.POST
+		r.Body = payload
+	}
+
+	retry := req.Retry{
+		Num:    max(c.Retries, 0) + 1,
+		Pause:  c.RetryPause,
+		Status: req.STATUS_OK,
+	}
+
+	instrumentation := c.getInstrumentation()
+
+	var info *RequestInfo
+
+	if instrumentation != nil {
+		info = c.startRequest(ctx, instrumentation, &r)
+	}
+
+	resp, attempts, err := doWithRetry(ctx, c.getEngine(), r, retry)
+
+	var result *PulseResult
+
+	if err == nil {
+		result, err = ParsePulseResponse(resp.String())
+	}
+
+	if info != nil {
+		info.Attempts, info.Error = attempts, err
+		info.Duration = time.Since(info.Start)
+
+		if resp != nil {
+			info.StatusCode = resp.StatusCode
+		}
+
+		instrumentation.RequestDone(info)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't send pulse request: %w", err)
+	}
+
+	return result, nil
+}
+
+// Heartbeat starts goroutine which sends pulse requests with given interval
+// until the context is cancelled. First pulse is sent immediately. Handler
+// is optional and called after every request. Returned channel is closed
+// when goroutine is stopped.
+func (c *PulseClient) Heartbeat(ctx context.Context, url string, interval time.Duration, handler PulseHandler) (<-chan struct{}, error) {
+	switch {
+	case c == nil:
+		return nil, ErrNilClient
+	case url == "":
+		return nil, ErrEmptyPulseURL
+	case interval <= 0:
+		return nil, ErrInvalidPulseInterval
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			result, err := c.SendContext(ctx, url, "")
+
+			if handler != nil && ctx.Err() == nil {
+				handler(result, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return done, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getEngine returns request engine
+func (c *PulseClient) getEngine() *req.Engine {
+	if c.HTTPClient == nil {
+		return req.Global
+	}
+
+	return &req.Engine{Client: c.HTTPClient}
+}
+
+// getInstrumentation returns instrumentation for pulse requests
+func (c *PulseClient) getInstrumentation() Instrumentation {
+	if c.Instrumentation != nil {
+		return c.Instrumentation
+	}
+
+	if i := pulseInstrumentation.Load(); i != nil {
+		return *i
+	}
+
+	return nil
+}
+
+// startRequest creates info about pulse request and notifies instrumentation
+// about request start
+func (c *PulseClient) startRequest(ctx context.Context, instrumentation Instrumentation, r *req.Request) *RequestInfo {
+	info := &RequestInfo{
+		Method: req.GET,
+		Route:  "/:token/:key",
+		Start:  time.Now(),
+	}
+
+	info.Endpoint, info.Token = parsePulseURL(r.URL)
+
+	if r.Method != "" {
+		info.Method = r.Method
+	}
+
+	r.Headers = req.Headers{}
+
+	startRequest(ctx, instrumentation, info, r.Headers)
+
+	return info
+}
@@ -0,0 +1,301 @@
+// Package pulse provides runner for pulse (cron) monitoring of commands and jobs
+package pulse
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/updown"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_OUTPUT_SIZE is default maximum size of captured output
+const DEFAULT_OUTPUT_SIZE = 4 * 1024
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Runner runs commands or functions and reports results to updown using pulse
+type Runner struct {
+	// URL is pulse URL
+	URL string
+
+	// MaxOutputSize is maximum size of captured output. Only the end of the
+	// output is kept.
+	MaxOutputSize int
+
+	// Timeout is maximum duration of command execution
+	Timeout time.Duration
+
+	// ReportFailures enables sending pulse with failure details as payload.
+	// By default pulse is sent only on success.
+	//
+	// Note that updown.io treats any pulse as a heartbeat regardless of its
+	// payload, so with this option enabled check stays up even if job is
+	// failing. Use it only if failures are checked using pulse payloads.
+	ReportFailures bool
+
+	// Stdout and Stderr are optional writers which receive command output
+	// along with capturing
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Client is pulse client used for sending pulse requests. If not set,
+	// client with default configuration is used.
+	Client *updown.PulseClient
+
+	send func(url, payload string) (string, error)
+}
+
+// Report contains info about execution
+type Report struct {
+	ExitCode    int           // Exit code (-1 if command wasn't started)
+	Duration    time.Duration // Execution duration
+	Output      string        // Captured output (stdout and stderr)
+	IsTruncated bool          // Output was truncated
+	Error       error         // Execution error
+	PulseUUID   string        // UUID of pulse request
+	PulseError  error         // Pulse request error
+}
+
+// Failure contains failure details sent as pulse payload
+type Failure struct {
+	ExitCode    int     `json:"exit_code"`
+	Duration    float64 `json:"duration"`
+	Error       string  `json:"error,omitempty"`
+	Output      string  `json:"output,omitempty"`
+	IsTruncated bool    `json:"truncated,omitempty"`
+}
+
+// Func is function which can be monitored by runner. Everything written to
+// given writer is captured as output.
+type Func func(ctx context.Context, output io.Writer) error
+
+// outputBuffer is buffer which keeps only the end of the output
+type outputBuffer struct {
+	mu        sync.Mutex
+	data      []byte
+	max       int
+	truncated bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilRunner = errors.New("Runner is nil")
+	ErrEmptyURL  = errors.New("Pulse URL is empty")
+	ErrEmptyCmd  = errors.New("Command is empty")
+	ErrNilFunc   = errors.New("Function is nil")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewRunner creates new runner for given pulse URL
+func NewRunner(url string) *Runner {
+	return &Runner{URL: url, MaxOutputSize: DEFAULT_OUTPUT_SIZE}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Run runs command and reports result
+func (r *Runner) Run(ctx context.Context, name string, args ...string) *Report {
+	switch {
+	case r == nil:
+		return &Report{ExitCode: -1, Error: ErrNilRunner}
+	case name == "":
+		return &Report{ExitCode: -1, Error: ErrEmptyCmd}
+	}
+
+	return r.run(ctx, func(ctx context.Context, output *outputBuffer) (int, error) {
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdout = r.writer(output, r.Stdout)
+		cmd.Stderr = r.writer(output, r.Stderr)
+
+		err := cmd.Run()
+
+		if cmd.ProcessState == nil {
+			return -1, err
+		}
+
+		return cmd.ProcessState.ExitCode(), err
+	})
+}
+
+// RunFunc runs function and reports result. Error returned by function is
+// treated as failure with exit code 1.
+func (r *Runner) RunFunc(ctx context.Context, fn Func) *Report {
+	switch {
+	case r == nil:
+		return &Report{ExitCode: -1, Error: ErrNilRunner}
+	case fn == nil:
+		return &Report{ExitCode: -1, Error: ErrNilFunc}
+	}
+
+	return r.run(ctx, func(ctx context.Context, output *outputBuffer) (int, error) {
+		err := fn(ctx, r.writer(output, r.Stdout))
+
+		if err != nil {
+			return 1, err
+		}
+
+		return 0, nil
+	})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsSuccess returns true if execution was successful
+func (r *Report) IsSuccess() bool {
+	return r != nil && r.ExitCode == 0 && r.Error == nil
+}
+
+// Failure returns failure details
+func (r *Report) Failure() *Failure {
+	if r == nil {
+		return nil
+	}
+
+	f := &Failure{
+		ExitCode:    r.ExitCode,
+		Duration:    r.Duration.Seconds(),
+		Output:      r.Output,
+		IsTruncated: r.IsTruncated,
+	}
+
+	if r.Error != nil {
+		f.Error = r.Error.Error()
+	}
+
+	return f
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// run executes given job and sends pulse
+func (r *Runner) run(ctx context.Context, job func(context.Context, *outputBuffer) (int, error)) *Report {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	maxSize := r.MaxOutputSize
+
+	if maxSize <= 0 {
+		maxSize = DEFAULT_OUTPUT_SIZE
+	}
+
+	output := &outputBuffer{max: maxSize}
+
+	start := time.Now()
+	exitCode, err := job(ctx, output)
+
+	result := &Report{
+		ExitCode: exitCode,
+		Duration: time.Since(start),
+		Error:    err,
+	}
+
+	result.Output = output.String()
+	result.IsTruncated = output.truncated
+
+	if r.URL == "" {
+		result.PulseError = ErrEmptyURL
+		return result
+	}
+
+	var payload string
+
+	if !result.IsSuccess() {
+		if !r.ReportFailures {
+			return result
+		}
+
+		data, _ := json.Marshal(result.Failure())
+		payload = string(data)
+	}
+
+	send := r.send
+
+	if send == nil {
+		send = r.sendPulse
+	}
+
+	result.PulseUUID, result.PulseError = send(r.URL, payload)
+
+	return result
+}
+
+// sendPulse sends pulse request using configured client
+func (r *Runner) sendPulse(url, payload string) (string, error) {
+	client := r.Client
+
+	if client == nil {
+		client = updown.NewPulseClient()
+	}
+
+	result, err := client.Send(url, payload)
+
+	if err != nil {
+		return "", err
+	}
+
+	return result.UUID, nil
+}
+
+// writer returns writer for capturing output
+func (r *Runner) writer(output *outputBuffer, w io.Writer) io.Writer {
+	if w == nil {
+		return output
+	}
+
+	return io.MultiWriter(output, w)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Write appends data to buffer
+func (b *outputBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = append(b.data, p...)
+
+	// Trim buffer only when it becomes twice bigger than limit to avoid
+	// copying on every write
+	if len(b.data) > b.max*2 {
+		b.data = append(b.data[:0], b.data[len(b.data)-b.max:]...)
+		b.truncated = true
+	}
+
+	return len(p), nil
+}
+
+// String returns captured output
+func (b *outputBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.data) > b.max {
+		b.data = b.data[len(b.data)-b.max:]
+		b.truncated = true
+	}
+
+	return string(b.data)
+}
@@ -0,0 +1,173 @@
+package pulse
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/updown"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type PulseSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&PulseSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *PulseSuite) TestErrors(c *C) {
+	var r *Runner
+
+	c.Assert(r.Run(context.Background(), "true").Error, Equals, ErrNilRunner)
+	c.Assert(r.RunFunc(context.Background(), nil).Error, Equals, ErrNilRunner)
+
+	r = NewRunner("")
+
+	c.Assert(r.Run(context.Background(), "").Error, Equals, ErrEmptyCmd)
+	c.Assert(r.RunFunc(context.Background(), nil).Error, Equals, ErrNilFunc)
+
+	res := r.Run(nil, "true")
+
+	c.Assert(res.IsSuccess(), Equals, true)
+	c.Assert(res.PulseError, Equals, ErrEmptyURL)
+
+	res = r.Run(context.Background(), "__unknown_command__")
+
+	c.Assert(res.IsSuccess(), Equals, false)
+	c.Assert(res.ExitCode, Equals, -1)
+
+	r.URL = "http://127.0.0.1:1/ngg8/abcd"
+	r.Client = updown.NewPulseClient()
+	r.Client.Retries = 0
+
+	res = r.Run(context.Background(), "true")
+
+	c.Assert(res.IsSuccess(), Equals, true)
+	c.Assert(res.PulseError, NotNil)
+
+	var nr *Report
+
+	c.Assert(nr.IsSuccess(), Equals, false)
+	c.Assert(nr.Failure(), IsNil)
+}
+
+func (s *PulseSuite) TestRun(c *C) {
+	var payloads []string
+
+	stdout := &bytes.Buffer{}
+
+	r := NewRunner("https://pulse.updown.io/ngg8/abcd")
+	r.Stdout = stdout
+	r.send = func(url, payload string) (string, error) {
+		payloads = append(payloads, payload)
+		return "ac0607d2", nil
+	}
+
+	res := r.Run(context.Background(), "sh", "-c", "echo test; echo error >&2")
+
+	c.Assert(res.IsSuccess(), Equals, true)
+	c.Assert(res.ExitCode, Equals, 0)
+	c.Assert(res.Output, HasLen, 11)
+	c.Assert(strings.Contains(res.Output, "test\n"), Equals, true)
+	c.Assert(strings.Contains(res.Output, "error\n"), Equals, true)
+	c.Assert(res.PulseUUID, Equals, "ac0607d2")
+	c.Assert(res.PulseError, IsNil)
+	c.Assert(stdout.String(), Equals, "test\n")
+	c.Assert(payloads, DeepEquals, []string{""})
+
+	res = r.Run(context.Background(), "sh", "-c", "exit 3")
+
+	c.Assert(res.IsSuccess(), Equals, false)
+	c.Assert(res.ExitCode, Equals, 3)
+	c.Assert(res.PulseUUID, Equals, "")
+	c.Assert(payloads, HasLen, 1)
+
+	r.ReportFailures = true
+	r.Timeout = 50 * time.Millisecond
+
+	res = r.Run(context.Background(), "sleep", "5")
+
+	c.Assert(res.IsSuccess(), Equals, false)
+	c.Assert(res.Duration < 5*time.Second, Equals, true)
+	c.Assert(payloads, HasLen, 2)
+
+	f := &Failure{}
+
+	c.Assert(json.Unmarshal([]byte(payloads[1]), f), IsNil)
+	c.Assert(f.ExitCode, Equals, -1)
+	c.Assert(f.Error, Not(Equals), "")
+}
+
+func (s *PulseSuite) TestRunFunc(c *C) {
+	var payloads []string
+
+	r := NewRunner("https://pulse.updown.io/ngg8/abcd")
+	r.ReportFailures = true
+	r.MaxOutputSize = 10
+	r.send = func(url, payload string) (string, error) {
+		payloads = append(payloads, payload)
+		return "", errors.New("Error")
+	}
+
+	res := r.RunFunc(context.Background(), func(ctx context.Context, w io.Writer) error {
+		fmt.Fprint(w, "0123456789ABCDEF")
+		return errors.New("Job failed")
+	})
+
+	c.Assert(res.IsSuccess(), Equals, false)
+	c.Assert(res.ExitCode, Equals, 1)
+	c.Assert(res.Output, Equals, "6789ABCDEF")
+	c.Assert(res.IsTruncated, Equals, true)
+	c.Assert(res.PulseError, NotNil)
+	c.Assert(payloads, HasLen, 1)
+	c.Assert(payloads[0], Matches, `\{"exit_code":1,"duration":.*,"error":"Job failed","output":"6789ABCDEF","truncated":true\}`)
+
+	r.MaxOutputSize = 0
+
+	res = r.RunFunc(context.Background(), func(ctx context.Context, w io.Writer) error {
+		fmt.Fprint(w, "ok")
+		return nil
+	})
+
+	c.Assert(res.IsSuccess(), Equals, true)
+	c.Assert(res.Output, Equals, "ok")
+	c.Assert(payloads, HasLen, 2)
+	c.Assert(payloads[1], Equals, "")
+}
+
+func (s *PulseSuite) TestOutputBuffer(c *C) {
+	b := &outputBuffer{max: 4}
+
+	for range 10 {
+		b.Write([]byte("abc"))
+	}
+
+	c.Assert(b.String(), Equals, "cabc")
+	c.Assert(b.truncated, Equals, true)
+
+	b = &outputBuffer{max: 4}
+	b.Write([]byte("abc"))
+
+	c.Assert(b.String(), Equals, "abc")
+	c.Assert(b.truncated, Equals, false)
+}
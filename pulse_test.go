@@ -0,0 +1,141 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestPulseResponse(c *C) {
+	r, err := ParsePulseResponse("OK: ac0607d2-3138-401f-8229-6ca473d03472\n")
+
+	c.Assert(err, IsNil)
+	c.Assert(r.Status, Equals, PULSE_STATUS_OK)
+	c.Assert(r.UUID, Equals, "ac0607d2-3138-401f-8229-6ca473d03472")
+	c.Assert(r.Raw, Equals, "OK: ac0607d2-3138-401f-8229-6ca473d03472\n")
+
+	_, err = ParsePulseResponse("")
+	c.Assert(err, Equals, ErrInvalidPulseResponse)
+	_, err = ParsePulseResponse("ERROR: ac0607d2-3138-401f-8229-6ca473d03472")
+	c.Assert(err, Equals, ErrInvalidPulseResponse)
+	_, err = ParsePulseResponse("OK: 1234")
+	c.Assert(err, Equals, ErrInvalidPulseResponse)
+}
+
+func (s *UpdownSuite) TestPulseClient(c *C) {
+	var pc *PulseClient
+
+	_, err := pc.Send("http://127.0.0.1:"+TEST_PORT+"/pulse", "")
+	c.Assert(err, Equals, ErrNilClient)
+
+	_, err = pc.Heartbeat(context.Background(), "http://127.0.0.1:"+TEST_PORT+"/pulse", time.Second, nil)
+	c.Assert(err, Equals, ErrNilClient)
+
+	pc = NewPulseClient()
+	pc.Retries = 0
+	pc.HTTPClient = &http.Client{}
+
+	inst := &testInstrumentation{}
+	pc.Instrumentation = inst
+
+	r, err := pc.Send("http://127.0.0.1:"+TEST_PORT+"/pulse", "")
+
+	c.Assert(err, IsNil)
+	c.Assert(r.UUID, Equals, "ac0607d2-3138-401f-8229-6ca473d03472")
+	c.Assert(inst.requests, HasLen, 1)
+	c.Assert(inst.requests[0].Method, Equals, "GET")
+
+	_, err = pc.Send("http://127.0.0.1:"+TEST_PORT+"/pulse-invalid", "")
+
+	c.Assert(err, ErrorMatches, "Can't send pulse request: Pulse response is not an acknowledgement")
+	c.Assert(inst.requests, HasLen, 2)
+	c.Assert(inst.requests[1].Error, NotNil)
+
+	_, err = pc.Send("http://127.0.0.1:"+TEST_PORT+"/pulse-error", "TEST-DATA")
+
+	c.Assert(err, ErrorMatches, "Can't send pulse request: Server returned non-ok status code 404")
+	c.Assert(inst.requests[2].Attempts, Equals, 1)
+
+	_, err = pc.Send("", "")
+	c.Assert(err, Equals, ErrEmptyPulseURL)
+}
+
+func (s *UpdownSuite) TestPulseClientContext(c *C) {
+	pc := NewPulseClient()
+	pc.Retries = 3
+	pc.RetryPause = time.Hour
+
+	inst := &testInstrumentation{}
+	pc.Instrumentation = inst
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := pc.SendContext(ctx, "http://127.0.0.1:"+TEST_PORT+"/pulse-error", "TEST-DATA")
+
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+	c.Assert(time.Since(start) < time.Second, Equals, true)
+	c.Assert(inst.requests, HasLen, 1)
+	c.Assert(inst.requests[0].Attempts, Equals, 1)
+	c.Assert(inst.requests[0].StatusCode, Equals, 404)
+
+	_, err = pc.SendContext(ctx, "http://127.0.0.1:"+TEST_PORT+"/pulse", "")
+
+	c.Assert(errors.Is(err, context.DeadlineExceeded), Equals, true)
+	c.Assert(inst.requests[1].Attempts, Equals, 0)
+
+	r, err := pc.SendContext(context.Background(), "http://127.0.0.1:"+TEST_PORT+"/pulse", "")
+
+	c.Assert(err, IsNil)
+	c.Assert(r.Status, Equals, PULSE_STATUS_OK)
+}
+
+func (s *UpdownSuite) TestPulseHeartbeat(c *C) {
+	var mu sync.Mutex
+	var results []*PulseResult
+
+	pc := NewPulseClient()
+
+	_, err := pc.Heartbeat(context.Background(), "", time.Second, nil)
+	c.Assert(err, Equals, ErrEmptyPulseURL)
+
+	_, err = pc.Heartbeat(context.Background(), "http://127.0.0.1:"+TEST_PORT+"/pulse", 0, nil)
+	c.Assert(err, Equals, ErrInvalidPulseInterval)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done, err := pc.Heartbeat(
+		ctx, "http://127.0.0.1:"+TEST_PORT+"/pulse", 10*time.Millisecond,
+		func(r *PulseResult, err error) {
+			mu.Lock()
+			results = append(results, r)
+			mu.Unlock()
+		},
+	)
+
+	c.Assert(err, IsNil)
+
+	time.Sleep(55 * time.Millisecond)
+	cancel()
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	c.Assert(len(results) >= 3, Equals, true)
+	c.Assert(results[0].UUID, Equals, "ac0607d2-3138-401f-8229-6ca473d03472")
+}
@@ -0,0 +1,504 @@
+// Package report provides generator of monthly availability reports for updown.io
+// checks
+package report
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"cmp"
+	"embed"
+	"errors"
+	"fmt"
+	htmlTemplate "html/template"
+	"io"
+	"slices"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/essentialkaos/updown"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	FORMAT_MARKDOWN = "markdown"
+	FORMAT_HTML     = "html"
+)
+
+// DEFAULT_TOP_SLOW is default number of checks in top slow checks list
+const DEFAULT_TOP_SLOW = 5
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Config contains report configuration
+type Config struct {
+	// Month is any moment within reported month. Location of this time is used
+	// for month bounds. Previous month is used if month is not set.
+	Month time.Time
+
+	// Checks is a list of tokens or aliases of reported checks. All checks are
+	// reported if list is empty.
+	Checks []string
+
+	// TopSlow is number of checks in top slow checks list
+	TopSlow int
+
+	// ExpirationDays is number of days used for SSL and domain expiration
+	// warnings
+	ExpirationDays int
+
+	// MarkdownTemplate is custom Markdown template (text/template). Embedded
+	// template is used if empty.
+	MarkdownTemplate string
+
+	// HTMLTemplate is custom HTML template (html/template). Embedded template
+	// is used if empty.
+	HTMLTemplate string
+}
+
+// Report contains monthly availability report data
+type Report struct {
+	From        time.Time // Month start
+	To          time.Time // Month end
+	GeneratedAt time.Time // Report generation date
+
+	Uptime float64 // Average uptime of all checks in percents
+
+	Checks         []*CheckReport // Per-check availability info
+	Incidents      []*Incident    // Incidents sorted by start date
+	SlowChecks     []*CheckReport // Checks with the slowest response time
+	SSLWarnings    []*Warning     // Problems with SSL certificates
+	DomainWarnings []*Warning     // Expiring domains
+
+	config Config
+}
+
+// CheckReport contains availability info for check
+type CheckReport struct {
+	Token string
+	Alias string
+	URL   string
+
+	Uptime       float64       // Uptime in percents
+	Apdex        float64       // Apdex score
+	ResponseTime time.Duration // Average response time
+	Incidents    int           // Number of incidents
+	Downtime     time.Duration // Total downtime within month
+
+	Metrics *updown.Metrics
+}
+
+// Incident contains info about check downtime
+type Incident struct {
+	Token     string
+	Alias     string
+	Error     string
+	StartedAt time.Time
+	EndedAt   time.Time // Zero if incident is still ongoing
+	Duration  time.Duration
+	IsPartial bool
+}
+
+// Warning contains info about SSL certificate or domain problem
+type Warning struct {
+	Token   string
+	Alias   string
+	Subject string // Check URL or domain name
+	Message string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+//go:embed templates/*
+var templates embed.FS
+
+// templateFuncs contains helpers available in templates
+var templateFuncs = map[string]any{
+	"month": func(t time.Time) string {
+		return t.Format("January 2006")
+	},
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return "—"
+		}
+
+		return t.Format("2006-01-02 15:04 MST")
+	},
+	"duration": formatDuration,
+	"percent": func(v float64) string {
+		return fmt.Sprintf("%.3f%%", v)
+	},
+	"apdex": func(v float64) string {
+		return fmt.Sprintf("%.2f", v)
+	},
+	"ms": func(d time.Duration) string {
+		return fmt.Sprintf("%d ms", d.Milliseconds())
+	},
+	"inc": func(i int) int {
+		return i + 1
+	},
+	"md": func(s string) string {
+		return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+	},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilClient     = errors.New("Client is nil")
+	ErrNoChecks      = errors.New("There are no checks for report")
+	ErrUnknownFormat = errors.New("Unknown report format")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Generate fetches checks, metrics and downtimes and creates availability report
+// for the month. Note that it requires two API requests per check.
+func Generate(client *updown.Client, cfg Config) (*Report, error) {
+	if client == nil {
+		return nil, ErrNilClient
+	}
+
+	now := time.Now()
+	from, to := monthBounds(cfg.Month, now)
+
+	checks, err := client.GetChecks()
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't fetch checks: %w", err)
+	}
+
+	if len(cfg.Checks) != 0 {
+		checks = checks.Select(cfg.Checks...)
+	}
+
+	if len(checks) == 0 {
+		return nil, ErrNoChecks
+	}
+
+	metrics := map[string]*updown.Metrics{}
+	downtimes := map[string]updown.Downtimes{}
+	options := updown.MetricsOptions{From: from, To: to}
+
+	if to.After(now) {
+		options.To = now
+	}
+
+	for _, c := range checks {
+		metrics[c.Token], err = client.GetMetrics(c.Token, options)
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't fetch metrics for check %q: %w", c.Token, err)
+		}
+
+		downtimes[c.Token], err = client.GetDowntimes(c.Token, false)
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't fetch downtimes for check %q: %w", c.Token, err)
+		}
+	}
+
+	return build(cfg, checks, metrics, downtimes, now), nil
+}
+
+// Template returns embedded template for given format. It can be used as
+// a starting point for custom templates.
+func Template(format string) (string, error) {
+	var file string
+
+	switch format {
+	case FORMAT_MARKDOWN:
+		file = "templates/report.md.tmpl"
+	case FORMAT_HTML:
+		file = "templates/report.html.tmpl"
+	default:
+		return "", ErrUnknownFormat
+	}
+
+	data, err := templates.ReadFile(file)
+
+	return string(data), err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Render renders report in given format to given writer
+func (r *Report) Render(w io.Writer, format string) error {
+	switch format {
+	case FORMAT_MARKDOWN:
+		return r.WriteMarkdown(w)
+	case FORMAT_HTML:
+		return r.WriteHTML(w)
+	}
+
+	return ErrUnknownFormat
+}
+
+// WriteMarkdown renders report as Markdown document to given writer
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	text := r.config.MarkdownTemplate
+
+	if text == "" {
+		text, _ = Template(FORMAT_MARKDOWN)
+	}
+
+	tmpl, err := textTemplate.New("report").Funcs(templateFuncs).Parse(text)
+
+	if err != nil {
+		return fmt.Errorf("Can't parse Markdown template: %w", err)
+	}
+
+	return tmpl.Execute(w, r)
+}
+
+// WriteHTML renders report as HTML document to given writer
+func (r *Report) WriteHTML(w io.Writer) error {
+	text := r.config.HTMLTemplate
+
+	if text == "" {
+		text, _ = Template(FORMAT_HTML)
+	}
+
+	tmpl, err := htmlTemplate.New("report").Funcs(templateFuncs).Parse(text)
+
+	if err != nil {
+		return fmt.Errorf("Can't parse HTML template: %w", err)
+	}
+
+	return tmpl.Execute(w, r)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Name returns check alias or URL if alias is empty
+func (c *CheckReport) Name() string {
+	return cmp.Or(c.Alias, c.URL, c.Token)
+}
+
+// IsOngoing returns true if incident is still ongoing
+func (i *Incident) IsOngoing() bool {
+	return i.EndedAt.IsZero()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// build creates report using fetched data
+func build(cfg Config, checks updown.Checks, metrics map[string]*updown.Metrics, downtimes map[string]updown.Downtimes, now time.Time) *Report {
+	from, to := monthBounds(cfg.Month, now)
+
+	if cfg.TopSlow <= 0 {
+		cfg.TopSlow = DEFAULT_TOP_SLOW
+	}
+
+	if cfg.ExpirationDays <= 0 {
+		cfg.ExpirationDays = updown.DEFAULT_EXPIRATION_DAYS
+	}
+
+	r := &Report{
+		From:        from,
+		To:          to,
+		GeneratedAt: now.In(from.Location()),
+		config:      cfg,
+	}
+
+	var withMetrics []*CheckReport
+
+	for _, c := range checks {
+		if c == nil {
+			continue
+		}
+
+		cr := &CheckReport{
+			Token:   c.Token,
+			Alias:   c.Alias,
+			URL:     c.URL,
+			Metrics: metrics[c.Token],
+		}
+
+		if hasMetrics(cr.Metrics) {
+			cr.Uptime = cr.Metrics.Uptime
+			cr.Apdex = cr.Metrics.Apdex
+
+			if cr.Metrics.Timings != nil && cr.Metrics.Timings.Total > 0 {
+				cr.ResponseTime = time.Duration(cr.Metrics.Timings.Total) * time.Millisecond
+				withMetrics = append(withMetrics, cr)
+			}
+		}
+
+		for _, d := range downtimes[c.Token] {
+			incident := newIncident(c, d, from, to, now)
+
+			if incident == nil {
+				continue
+			}
+
+			cr.Incidents++
+
+			if !incident.IsPartial {
+				cr.Downtime += incident.Duration
+			}
+
+			r.Incidents = append(r.Incidents, incident)
+		}
+
+		// Uptime of checks without metrics is calculated using downtimes
+		if !hasMetrics(cr.Metrics) {
+			period := to.Sub(from)
+			cr.Uptime = 100
+
+			if now.Before(to) {
+				period = now.Sub(from)
+			}
+
+			if period > 0 {
+				cr.Uptime = max(0, 100*(1-float64(cr.Downtime)/float64(period)))
+			}
+		}
+
+		r.Uptime += cr.Uptime
+		r.Checks = append(r.Checks, cr)
+	}
+
+	if len(r.Checks) != 0 {
+		r.Uptime /= float64(len(r.Checks))
+	}
+
+	slices.SortStableFunc(r.Checks, func(a, b *CheckReport) int {
+		return cmp.Compare(a.Uptime, b.Uptime)
+	})
+
+	slices.SortStableFunc(r.Incidents, func(a, b *Incident) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+
+	slices.SortStableFunc(withMetrics, func(a, b *CheckReport) int {
+		return cmp.Compare(b.ResponseTime, a.ResponseTime)
+	})
+
+	r.SlowChecks = withMetrics[:min(len(withMetrics), cfg.TopSlow)]
+	r.SSLWarnings, r.DomainWarnings = getWarnings(checks, cfg.ExpirationDays, now)
+
+	return r
+}
+
+// newIncident creates incident from downtime if it overlaps with given period
+func newIncident(c *updown.Check, d *updown.Downtime, from, to, now time.Time) *Incident {
+	if d == nil || d.StartedAt.IsZero() {
+		return nil
+	}
+
+	start, end := d.StartedAt.Time, d.EndedAt.Time
+
+	if !start.Before(to) || (!end.IsZero() && !end.After(from)) {
+		return nil
+	}
+
+	incident := &Incident{
+		Token:     c.Token,
+		Alias:     c.Alias,
+		Error:     d.Error,
+		StartedAt: start.In(from.Location()),
+		IsPartial: d.IsPartial,
+	}
+
+	if !end.IsZero() {
+		incident.EndedAt = end.In(from.Location())
+	}
+
+	// Duration is limited by month bounds
+	switch {
+	case end.IsZero() && now.Before(to):
+		end = now
+	case end.IsZero() || end.After(to):
+		end = to
+	}
+
+	if start.Before(from) {
+		start = from
+	}
+
+	if end.After(start) {
+		incident.Duration = end.Sub(start)
+	}
+
+	return incident
+}
+
+// getWarnings returns SSL and domain warnings for checks
+func getWarnings(checks updown.Checks, days int, now time.Time) ([]*Warning, []*Warning) {
+	var sslWarnings, domainWarnings []*Warning
+
+	for _, c := range checks.InvalidSSL() {
+		sslWarnings = append(sslWarnings, &Warning{
+			Token:   c.Token,
+			Alias:   c.Alias,
+			Subject: c.URL,
+			Message: "Invalid certificate: " + cmp.Or(c.SSL.Error, "unknown error"),
+		})
+	}
+
+	for _, c := range checks.ExpiringSSL(days) {
+		sslWarnings = append(sslWarnings, &Warning{
+			Token:   c.Token,
+			Alias:   c.Alias,
+			Subject: c.URL,
+			Message: fmt.Sprintf(
+				"Certificate expires in %d days (%s)",
+				int(c.SSL.ExpiresAt.Sub(now).Hours()/24),
+				c.SSL.ExpiresAt.Format("2006-01-02"),
+			),
+		})
+	}
+
+	for _, d := range updown.NewDomainReport(checks).Expiring(days) {
+		domainWarnings = append(domainWarnings, &Warning{
+			Token:   d.Checks[0],
+			Alias:   checks.Get(d.Checks[0]).Alias,
+			Subject: d.Name,
+			Message: fmt.Sprintf(
+				"Domain expires in %d days (%s)",
+				d.RemainingDays, d.ExpiresAt.Format("2006-01-02"),
+			),
+		})
+	}
+
+	return sslWarnings, domainWarnings
+}
+
+// hasMetrics returns true if metrics contain any data
+func hasMetrics(m *updown.Metrics) bool {
+	return m != nil && (m.Uptime != 0 || m.Timings != nil || m.Requests != nil)
+}
+
+// monthBounds returns start and end of the month
+func monthBounds(month, now time.Time) (time.Time, time.Time) {
+	if month.IsZero() {
+		month = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+	}
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+
+	return from, from.AddDate(0, 1, 0)
+}
+
+// formatDuration formats duration in human-readable form
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+
+	switch {
+	case d < time.Minute:
+		return d.String()
+	case d < time.Hour:
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+
+	return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+}
@@ -0,0 +1,223 @@
+package report
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/updown"
+	"github.com/essentialkaos/updown/updowntest"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type ReportSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&ReportSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *ReportSuite) TestGenerate(c *C) {
+	_, err := Generate(nil, Config{})
+	c.Assert(err, Equals, ErrNilClient)
+
+	srv := updowntest.NewServer()
+	defer srv.Close()
+
+	_, err = Generate(srv.Client(), Config{})
+	c.Assert(err, Equals, ErrNoChecks)
+
+	month := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	srv.AddCheck(&updown.Check{
+		Token: "api1", Alias: "API", URL: "https://api.example.com",
+		SSL: &updown.SSLStatus{
+			TestedAt: updown.Date{Time: now}, ExpiresAt: updown.Date{Time: now.AddDate(0, 0, 5)}, IsValid: true,
+		},
+	})
+	srv.AddCheck(&updown.Check{
+		Token: "web1", URL: "https://www.example.com",
+		Domain: &updown.Domain{
+			TestedAt: updown.Date{Time: now}, ExpiresAt: updown.Date{Time: now.AddDate(0, 0, 3)}, RemainingDays: 3,
+		},
+	})
+	srv.AddCheck(&updown.Check{Token: "off1", URL: "https://off.example.com"})
+
+	srv.SetMetrics("api1", &updown.Metrics{
+		Uptime: 99.5, Apdex: 0.9, Timings: &updown.TimingStats{Total: 350},
+	})
+	srv.SetMetrics("web1", &updown.Metrics{
+		Uptime: 100, Apdex: 1, Timings: &updown.TimingStats{Total: 120},
+	})
+
+	srv.AddDowntime("api1", &updown.Downtime{
+		Error:     "500 Internal Server Error",
+		StartedAt: updown.Date{Time: time.Date(2025, 1, 31, 23, 50, 0, 0, time.UTC)},
+		EndedAt:   updown.Date{Time: time.Date(2025, 2, 1, 0, 10, 0, 0, time.UTC)},
+	})
+	srv.AddDowntime("api1", &updown.Downtime{
+		Error:     "Timeout | 30s",
+		StartedAt: updown.Date{Time: time.Date(2025, 2, 14, 12, 0, 0, 0, time.UTC)},
+		EndedAt:   updown.Date{Time: time.Date(2025, 2, 14, 13, 30, 0, 0, time.UTC)},
+		IsPartial: true,
+	})
+	srv.AddDowntime("api1", &updown.Downtime{
+		StartedAt: updown.Date{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	srv.AddDowntime("web1", &updown.Downtime{
+		Error:     "Connection refused",
+		StartedAt: updown.Date{Time: time.Date(2025, 2, 28, 23, 0, 0, 0, time.UTC)},
+	})
+
+	_, err = Generate(srv.Client(), Config{Checks: []string{"unknown"}})
+	c.Assert(err, Equals, ErrNoChecks)
+
+	r, err := Generate(srv.Client(), Config{
+		Month: month, Checks: []string{"API", "web1", "off1"}, TopSlow: 1,
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(r.From, Equals, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(r.To, Equals, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(r.Checks, HasLen, 3)
+	c.Assert(r.Checks[0].Token, Equals, "api1")
+	c.Assert(r.Checks[0].Incidents, Equals, 2)
+	c.Assert(r.Checks[0].Downtime, Equals, 10*time.Minute)
+	c.Assert(r.Checks[0].ResponseTime, Equals, 350*time.Millisecond)
+	c.Assert(r.Checks[1].Downtime, Equals, time.Hour)
+	c.Assert(r.Checks[2].Token, Equals, "off1")
+	c.Assert(r.Checks[2].Uptime, Equals, 100.0)
+	c.Assert(r.Incidents, HasLen, 3)
+	c.Assert(r.Incidents[0].Duration, Equals, 10*time.Minute)
+	c.Assert(r.Incidents[2].IsOngoing(), Equals, true)
+	c.Assert(r.SlowChecks, HasLen, 1)
+	c.Assert(r.SlowChecks[0].Name(), Equals, "API")
+	c.Assert(r.SSLWarnings, HasLen, 1)
+	c.Assert(r.DomainWarnings, HasLen, 1)
+	c.Assert(r.DomainWarnings[0].Subject, Equals, "example.com")
+
+	var buf bytes.Buffer
+
+	c.Assert(r.Render(&buf, FORMAT_MARKDOWN), IsNil)
+
+	md := buf.String()
+
+	c.Assert(strings.HasPrefix(md, "# Availability report: February 2025"), Equals, true)
+	c.Assert(md, Matches, `(?s).*\| \[API\]\(https://api.example.com\) \| 99.500% \| 0.90 \| 350 ms \| 2 \| 10m 0s \|.*`)
+	c.Assert(md, Matches, `(?s).*\| 1h 30m \(partial\) \| Timeout \\| 30s \|.*`)
+	c.Assert(md, Matches, `(?s).*\| web1 \| 2025-02-28 23:00 UTC \| ongoing \| 1h 0m \|.*`)
+	c.Assert(md, Matches, `(?s).*1\. \*\*API\*\* — 350 ms.*`)
+	c.Assert(md, Matches, `(?s).*Certificate expires in [45] days.*`)
+	c.Assert(md, Matches, `(?s).*\*\*example.com\*\*: Domain expires in 3 days.*`)
+
+	buf.Reset()
+
+	c.Assert(r.Render(&buf, FORMAT_HTML), IsNil)
+
+	html := buf.String()
+
+	c.Assert(strings.HasPrefix(html, "<!DOCTYPE html>"), Equals, true)
+	c.Assert(html, Matches, `(?s).*<td>Timeout \| 30s</td>.*`)
+	c.Assert(html, Matches, `(?s).*<td class="bad">99.500%</td>.*`)
+
+	c.Assert(r.Render(&buf, "pdf"), Equals, ErrUnknownFormat)
+
+	srv.InjectError("GET /checks/:token/downtimes", 500)
+	_, err = Generate(srv.Client(), Config{Month: month})
+	c.Assert(err, ErrorMatches, `Can't fetch downtimes for check .*`)
+
+	srv.InjectError("GET /checks/:token/metrics", 500)
+	_, err = Generate(srv.Client(), Config{Month: month})
+	c.Assert(err, ErrorMatches, `Can't fetch metrics for check .*`)
+
+	srv.InjectError("*", 500)
+	_, err = Generate(srv.Client(), Config{Month: month})
+	c.Assert(err, ErrorMatches, `Can't fetch checks: .*`)
+}
+
+func (s *ReportSuite) TestTemplates(c *C) {
+	md, err := Template(FORMAT_MARKDOWN)
+	c.Assert(err, IsNil)
+	c.Assert(md, Not(Equals), "")
+
+	html, err := Template(FORMAT_HTML)
+	c.Assert(err, IsNil)
+	c.Assert(html, Not(Equals), "")
+
+	_, err = Template("pdf")
+	c.Assert(err, Equals, ErrUnknownFormat)
+
+	r := &Report{
+		From: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		Checks: []*CheckReport{
+			{Token: "abcd", Uptime: 100},
+		},
+		config: Config{
+			MarkdownTemplate: `{{ month .From }}:{{ range .Checks }} {{ .Name }} {{ percent .Uptime }}{{ end }}`,
+			HTMLTemplate:     `<b>{{ (index .Checks 0).Token }}</b>`,
+		},
+	}
+
+	var buf bytes.Buffer
+
+	c.Assert(r.WriteMarkdown(&buf), IsNil)
+	c.Assert(buf.String(), Equals, "February 2025: abcd 100.000%")
+
+	buf.Reset()
+
+	c.Assert(r.WriteHTML(&buf), IsNil)
+	c.Assert(buf.String(), Equals, "<b>abcd</b>")
+
+	r.config.MarkdownTemplate = "{{ .Unknown"
+	r.config.HTMLTemplate = "{{ .Unknown"
+
+	c.Assert(r.WriteMarkdown(&buf), ErrorMatches, `Can't parse Markdown template: .*`)
+	c.Assert(r.WriteHTML(&buf), ErrorMatches, `Can't parse HTML template: .*`)
+
+	r.config = Config{}
+	buf.Reset()
+
+	c.Assert(r.WriteMarkdown(&buf), IsNil)
+	c.Assert(buf.String(), Matches, `(?s).*No incidents.*`)
+}
+
+func (s *ReportSuite) TestHelpers(c *C) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	from, to := monthBounds(time.Time{}, now)
+
+	c.Assert(from, Equals, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(to, Equals, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	c.Assert(formatDuration(42*time.Second), Equals, "42s")
+	c.Assert(formatDuration(90*time.Minute), Equals, "1h 30m")
+	c.Assert(formatDuration(50*time.Hour), Equals, "2d 2h")
+
+	check := &updown.Check{Token: "abcd"}
+
+	c.Assert(newIncident(check, nil, from, to, now), IsNil)
+	c.Assert(newIncident(check, &updown.Downtime{
+		StartedAt: updown.Date{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		EndedAt:   updown.Date{Time: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}, from, to, now), IsNil)
+
+	i := newIncident(check, &updown.Downtime{
+		StartedAt: updown.Date{Time: time.Date(2025, 3, 31, 11, 0, 0, 0, time.UTC)},
+	}, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), now)
+
+	c.Assert(i.Duration, Equals, time.Hour)
+}
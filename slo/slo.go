@@ -0,0 +1,348 @@
+// Package slo provides SLO and error budget calculator based on updown.io metrics
+// and downtimes
+package slo
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/essentialkaos/updown"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	// WINDOW_ROLLING_30D is rolling window with last 30 days
+	WINDOW_ROLLING_30D Window = iota
+
+	// WINDOW_CALENDAR_MONTH is window with current calendar month
+	WINDOW_CALENDAR_MONTH
+)
+
+const (
+	SEVERITY_PAGE   = "page"
+	SEVERITY_TICKET = "ticket"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Window is SLO window type
+type Window uint8
+
+// Objective contains service level objective configuration
+type Objective struct {
+	Token   string          // Check token
+	Target  float64         // Target uptime in percents (e.g. 99.9)
+	Window  Window          // Window type
+	Windows []time.Duration // Burn rate windows (DefaultWindows is used if empty)
+}
+
+// Report contains error budget info
+type Report struct {
+	Objective Objective
+
+	Start time.Time // Window start
+	End   time.Time // Window end
+
+	Budget         time.Duration // Total error budget for the window
+	Consumed       time.Duration // Consumed error budget
+	Remaining      time.Duration // Remaining error budget (can be negative)
+	RemainingRatio float64       // Remaining error budget ratio (1 → untouched, 0 → exhausted)
+
+	BurnRates BurnRates // Burn rates sorted by window size
+
+	ExhaustionAt time.Time // Projected budget exhaustion date (zero if budget isn't burning)
+}
+
+// BurnRate contains error budget burn rate for specific window
+type BurnRate struct {
+	Window time.Duration // Window size
+	Uptime float64       // Uptime for the window in percents
+	Rate   float64       // Burn rate (1 → budget will be exhausted exactly at the window end)
+}
+
+// BurnRates is a slice with burn rates
+type BurnRates []*BurnRate
+
+// AlertRule is multi-window burn rate alert rule
+type AlertRule struct {
+	Severity  string        // Alert severity
+	Long      time.Duration // Long window
+	Short     time.Duration // Short window (optional)
+	Threshold float64       // Burn rate threshold
+}
+
+// Alert contains info about triggered alert rule
+type Alert struct {
+	Rule      AlertRule
+	LongRate  float64
+	ShortRate float64
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DefaultWindows is default set of windows for burn rate calculation
+var DefaultWindows = []time.Duration{
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+	72 * time.Hour,
+}
+
+// DefaultAlertRules is default set of multi-window burn rate alert rules
+var DefaultAlertRules = []AlertRule{
+	{Severity: SEVERITY_PAGE, Long: time.Hour, Threshold: 14.4},
+	{Severity: SEVERITY_PAGE, Long: 6 * time.Hour, Short: time.Hour, Threshold: 6},
+	{Severity: SEVERITY_TICKET, Long: 24 * time.Hour, Short: 6 * time.Hour, Threshold: 3},
+	{Severity: SEVERITY_TICKET, Long: 72 * time.Hour, Short: 24 * time.Hour, Threshold: 1},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrNilClient     = errors.New("Client is nil")
+	ErrEmptyToken    = errors.New("Token is empty")
+	ErrInvalidTarget = errors.New("Target must be greater than 0 and less than 100")
+	ErrInvalidWindow = errors.New("Unknown window type")
+	ErrInvalidSize   = errors.New("Burn rate window must be greater than 0")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Calculate fetches metrics and downtimes for the check and calculates error budget
+// report
+func Calculate(client *updown.Client, obj Objective) (*Report, error) {
+	if client == nil {
+		return nil, ErrNilClient
+	}
+
+	err := obj.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	downtimes, err := client.GetDowntimes(obj.Token, false)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't fetch downtimes: %w", err)
+	}
+
+	metrics := map[time.Duration]*updown.Metrics{}
+
+	for _, w := range obj.windows() {
+		if _, ok := metrics[w]; ok {
+			continue
+		}
+
+		m, err := client.GetMetrics(obj.Token, updown.MetricsOptions{
+			From: now.Add(-w), To: now,
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't fetch metrics for %v window: %w", w, err)
+		}
+
+		metrics[w] = m
+	}
+
+	return calculate(obj, now, downtimes, metrics), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates objective
+func (o Objective) Validate() error {
+	switch {
+	case o.Token == "":
+		return ErrEmptyToken
+	case o.Target <= 0 || o.Target >= 100:
+		return ErrInvalidTarget
+	case o.Window > WINDOW_CALENDAR_MONTH:
+		return ErrInvalidWindow
+	}
+
+	for _, w := range o.Windows {
+		if w <= 0 {
+			return ErrInvalidSize
+		}
+	}
+
+	return nil
+}
+
+// Bounds returns window start and end for given date
+func (w Window) Bounds(now time.Time) (time.Time, time.Time) {
+	switch w {
+	case WINDOW_CALENDAR_MONTH:
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 1, 0)
+	}
+
+	return now.Add(-30 * 24 * time.Hour), now
+}
+
+// String returns window name
+func (w Window) String() string {
+	switch w {
+	case WINDOW_ROLLING_30D:
+		return "rolling-30d"
+	case WINDOW_CALENDAR_MONTH:
+		return "calendar-month"
+	}
+
+	return "unknown"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns burn rate for window with given size
+func (b BurnRates) Get(window time.Duration) *BurnRate {
+	for _, br := range b {
+		if br.Window == window {
+			return br
+		}
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsExhausted returns true if error budget is exhausted
+func (r *Report) IsExhausted() bool {
+	return r != nil && r.Remaining <= 0
+}
+
+// Evaluate evaluates multi-window burn rate alert rules and returns triggered
+// alerts. Rules which use windows without burn rate data are ignored.
+func (r *Report) Evaluate(rules []AlertRule) []*Alert {
+	if r == nil {
+		return nil
+	}
+
+	var result []*Alert
+
+	for _, rule := range rules {
+		long := r.BurnRates.Get(rule.Long)
+
+		if long == nil || long.Rate < rule.Threshold {
+			continue
+		}
+
+		alert := &Alert{Rule: rule, LongRate: long.Rate}
+
+		if rule.Short != 0 {
+			short := r.BurnRates.Get(rule.Short)
+
+			if short == nil || short.Rate < rule.Threshold {
+				continue
+			}
+
+			alert.ShortRate = short.Rate
+		}
+
+		result = append(result, alert)
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// calculate calculates error budget report
+func calculate(obj Objective, now time.Time, downtimes updown.Downtimes, metrics map[time.Duration]*updown.Metrics) *Report {
+	start, end := obj.Window.Bounds(now)
+	allowed := 1 - obj.Target/100
+
+	r := &Report{
+		Objective: obj,
+		Start:     start,
+		End:       end,
+		Budget:    time.Duration(float64(end.Sub(start)) * allowed),
+		Consumed:  downtimeWithin(downtimes, start, now),
+	}
+
+	r.Remaining = r.Budget - r.Consumed
+
+	if r.Budget > 0 {
+		r.RemainingRatio = float64(r.Remaining) / float64(r.Budget)
+	}
+
+	for _, w := range obj.windows() {
+		m := metrics[w]
+
+		if m == nil || r.BurnRates.Get(w) != nil {
+			continue
+		}
+
+		r.BurnRates = append(r.BurnRates, &BurnRate{
+			Window: w,
+			Uptime: m.Uptime,
+			Rate:   ((100 - m.Uptime) / 100) / allowed,
+		})
+	}
+
+	sort.Slice(r.BurnRates, func(i, j int) bool {
+		return r.BurnRates[i].Window < r.BurnRates[j].Window
+	})
+
+	switch {
+	case r.Remaining <= 0:
+		r.ExhaustionAt = now
+	case len(r.BurnRates) != 0 && r.BurnRates[0].Rate > 0:
+		// Current burn rate is taken from the shortest window
+		speed := r.BurnRates[0].Rate * allowed
+		r.ExhaustionAt = now.Add(time.Duration(float64(r.Remaining) / speed))
+	}
+
+	return r
+}
+
+// windows returns burn rate windows of objective
+func (o Objective) windows() []time.Duration {
+	if len(o.Windows) == 0 {
+		return DefaultWindows
+	}
+
+	return o.Windows
+}
+
+// downtimeWithin returns total duration of downtimes within given period. Partial
+// downtimes are ignored because they don't affect check uptime.
+func downtimeWithin(downtimes updown.Downtimes, from, to time.Time) time.Duration {
+	var result time.Duration
+
+	for _, d := range downtimes {
+		if d == nil || d.IsPartial || d.StartedAt.IsZero() {
+			continue
+		}
+
+		start, end := d.StartedAt.Time, d.EndedAt.Time
+
+		if end.IsZero() || end.After(to) {
+			end = to
+		}
+
+		if start.Before(from) {
+			start = from
+		}
+
+		if end.After(start) {
+			result += end.Sub(start)
+		}
+	}
+
+	return result
+}
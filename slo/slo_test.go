@@ -0,0 +1,161 @@
+package slo
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/updown"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type SLOSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&SLOSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *SLOSuite) TestValidation(c *C) {
+	c.Assert(Objective{Target: 99.9}.Validate(), Equals, ErrEmptyToken)
+	c.Assert(Objective{Token: "ngg8", Target: 0}.Validate(), Equals, ErrInvalidTarget)
+	c.Assert(Objective{Token: "ngg8", Target: 100}.Validate(), Equals, ErrInvalidTarget)
+	c.Assert(Objective{Token: "ngg8", Target: 99.9, Window: 10}.Validate(), Equals, ErrInvalidWindow)
+	c.Assert(Objective{Token: "ngg8", Target: 99.9, Windows: []time.Duration{time.Hour, 0}}.Validate(), Equals, ErrInvalidSize)
+	c.Assert(Objective{Token: "ngg8", Target: 99.9}.Validate(), IsNil)
+
+	_, err := Calculate(nil, Objective{Token: "ngg8", Target: 99.9})
+	c.Assert(err, Equals, ErrNilClient)
+
+	client, _ := updown.NewClient("test1234")
+	_, err = Calculate(client, Objective{Token: "ngg8"})
+	c.Assert(err, Equals, ErrInvalidTarget)
+}
+
+func (s *SLOSuite) TestWindow(c *C) {
+	now := time.Date(2025, 2, 14, 12, 0, 0, 0, time.UTC)
+
+	start, end := WINDOW_ROLLING_30D.Bounds(now)
+	c.Assert(start, Equals, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC))
+	c.Assert(end, Equals, now)
+
+	start, end = WINDOW_CALENDAR_MONTH.Bounds(now)
+	c.Assert(start, Equals, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(end, Equals, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	c.Assert(WINDOW_ROLLING_30D.String(), Equals, "rolling-30d")
+	c.Assert(WINDOW_CALENDAR_MONTH.String(), Equals, "calendar-month")
+	c.Assert(Window(10).String(), Equals, "unknown")
+}
+
+func (s *SLOSuite) TestCalculate(c *C) {
+	now := time.Date(2025, 2, 14, 12, 0, 0, 0, time.UTC)
+
+	downtimes := updown.Downtimes{
+		// Ongoing downtime
+		{StartedAt: date(now.Add(-10 * time.Minute))},
+		// Partial downtime must be ignored
+		{StartedAt: date(now.Add(-time.Hour)), EndedAt: date(now.Add(-50 * time.Minute)), IsPartial: true},
+		{StartedAt: date(now.Add(-48 * time.Hour)), EndedAt: date(now.Add(-47 * time.Hour))},
+		// Downtime outside the window
+		{StartedAt: date(now.Add(-60 * 24 * time.Hour)), EndedAt: date(now.Add(-59 * 24 * time.Hour))},
+		nil,
+	}
+
+	metrics := map[time.Duration]*updown.Metrics{
+		time.Hour:      {Uptime: 99.0},
+		6 * time.Hour:  {Uptime: 99.5},
+		24 * time.Hour: {Uptime: 99.9},
+		72 * time.Hour: nil,
+	}
+
+	r := calculate(Objective{Token: "ngg8", Target: 99.0}, now, downtimes, metrics)
+
+	c.Assert(r, NotNil)
+	c.Assert(r.Budget, Equals, 432*time.Minute)
+	c.Assert(r.Consumed, Equals, 70*time.Minute)
+	c.Assert(r.Remaining, Equals, 362*time.Minute)
+	c.Assert(r.RemainingRatio > 0.83 && r.RemainingRatio < 0.84, Equals, true)
+	c.Assert(r.IsExhausted(), Equals, false)
+	c.Assert(r.BurnRates, HasLen, 3)
+	c.Assert(r.BurnRates[0].Window, Equals, time.Hour)
+	c.Assert(r.BurnRates[0].Rate > 0.99 && r.BurnRates[0].Rate < 1.01, Equals, true)
+	c.Assert(r.BurnRates.Get(6*time.Hour).Uptime, Equals, 99.5)
+	c.Assert(r.BurnRates.Get(72*time.Hour), IsNil)
+	c.Assert(r.ExhaustionAt.Sub(now).Round(time.Hour), Equals, 603*time.Hour)
+
+	r = calculate(
+		Objective{Token: "ngg8", Target: 99.0, Windows: []time.Duration{6 * time.Hour, 24 * time.Hour, 6 * time.Hour}},
+		now, downtimes, metrics,
+	)
+
+	c.Assert(r.BurnRates, HasLen, 2)
+	c.Assert(r.BurnRates[0].Window, Equals, 6*time.Hour)
+	c.Assert(r.BurnRates.Get(time.Hour), IsNil)
+
+	r = calculate(
+		Objective{Token: "ngg8", Target: 99.99, Window: WINDOW_CALENDAR_MONTH},
+		now, downtimes, nil,
+	)
+
+	c.Assert(r.IsExhausted(), Equals, true)
+	c.Assert(r.ExhaustionAt, Equals, now)
+	c.Assert(r.BurnRates, HasLen, 0)
+
+	r = calculate(Objective{Token: "ngg8", Target: 99.0}, now, nil, nil)
+
+	c.Assert(r.Remaining, Equals, r.Budget)
+	c.Assert(r.ExhaustionAt.IsZero(), Equals, true)
+}
+
+func (s *SLOSuite) TestEvaluate(c *C) {
+	var r *Report
+
+	c.Assert(r.IsExhausted(), Equals, false)
+	c.Assert(r.Evaluate(DefaultAlertRules), IsNil)
+
+	r = &Report{
+		BurnRates: BurnRates{
+			{Window: time.Hour, Rate: 20},
+			{Window: 6 * time.Hour, Rate: 7},
+			{Window: 24 * time.Hour, Rate: 2},
+			{Window: 72 * time.Hour, Rate: 0.5},
+		},
+	}
+
+	alerts := r.Evaluate(DefaultAlertRules)
+
+	c.Assert(alerts, HasLen, 2)
+	c.Assert(alerts[0].Rule.Severity, Equals, SEVERITY_PAGE)
+	c.Assert(alerts[0].LongRate, Equals, 20.0)
+	c.Assert(alerts[0].ShortRate, Equals, 0.0)
+	c.Assert(alerts[1].Rule.Severity, Equals, SEVERITY_PAGE)
+	c.Assert(alerts[1].LongRate, Equals, 7.0)
+	c.Assert(alerts[1].ShortRate, Equals, 20.0)
+
+	alerts = r.Evaluate([]AlertRule{
+		{Severity: SEVERITY_TICKET, Long: 72 * time.Hour, Short: 2 * time.Hour, Threshold: 0.5},
+		{Severity: SEVERITY_TICKET, Long: 72 * time.Hour, Short: 24 * time.Hour, Threshold: 0.5},
+	})
+
+	c.Assert(alerts, HasLen, 1)
+	c.Assert(alerts[0].ShortRate, Equals, 2.0)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func date(t time.Time) updown.Date {
+	return updown.Date{Time: t}
+}
@@ -0,0 +1,378 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SSLReport contains info about SSL certificates of checks
+type SSLReport struct {
+	GeneratedAt  Date             `json:"generated_at"`
+	Certificates []*SSLReportItem `json:"certificates"`
+	Renewals     []*SSLRenewal    `json:"renewals,omitempty"`
+}
+
+// SSLReportItem contains info about SSL certificate of check
+type SSLReportItem struct {
+	Token         string `json:"token"`
+	Alias         string `json:"alias,omitempty"`
+	URL           string `json:"url"`
+	Subject       string `json:"subject,omitempty"`
+	Issuer        string `json:"issuer,omitempty"`
+	Algorithm     string `json:"algorithm,omitempty"`
+	ValidFrom     Date   `json:"valid_from"`
+	ValidTo       Date   `json:"valid_to"`
+	DaysRemaining int    `json:"days_remaining"` // -1 if expiration date is unknown
+	IsValid       bool   `json:"valid"`
+	IsWeak        bool   `json:"weak"`
+	Error         string `json:"error,omitempty"`
+	TestedAt      Date   `json:"tested_at"`
+}
+
+// SSLRenewal contains info about certificate renewal
+type SSLRenewal struct {
+	Token   string        `json:"token"`
+	Alias   string        `json:"alias,omitempty"`
+	URL     string        `json:"url"`
+	Time    Date          `json:"time"`
+	OldCert *Cert         `json:"old_cert"`
+	NewCert *Cert         `json:"new_cert"`
+	Changes []*CertChange `json:"changes"`
+}
+
+// CertChange contains info about changed certificate field
+type CertChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// weakAlgorithms is slice with markers of weak signature algorithms
+var weakAlgorithms = []string{"md2", "md4", "md5", "sha1", "sha-1"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewSSLReport creates SSL report for given checks. Checks contain only basic
+// info about certificate status, so info about certificates (subject, issuer,
+// algorithm and validity) is taken from SSL webhook events. Events are also
+// used for collecting renewals.
+func NewSSLReport(checks Checks, events Webhook) *SSLReport {
+	return newSSLReport(checks, events, time.Now())
+}
+
+// IsWeakAlgorithm returns true if given signature algorithm is considered weak
+// (e.g. MD5 or SHA-1)
+func IsWeakAlgorithm(algorithm string) bool {
+	algorithm = strings.ToLower(algorithm)
+
+	for _, m := range weakAlgorithms {
+		if strings.Contains(algorithm, m) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DiffCerts returns list of changed fields between two certificates
+func DiffCerts(old, new *Cert) []*CertChange {
+	if old == nil {
+		old = &Cert{}
+	}
+
+	if new == nil {
+		new = &Cert{}
+	}
+
+	var result []*CertChange
+
+	fields := [][3]string{
+		{"subject", old.Subject, new.Subject},
+		{"issuer", old.Issuer, new.Issuer},
+		{"algorithm", old.Algorithm, new.Algorithm},
+		{"from", formatReportDate(old.From), formatReportDate(new.From)},
+		{"to", formatReportDate(old.To), formatReportDate(new.To)},
+	}
+
+	for _, f := range fields {
+		if f[1] != f[2] {
+			result = append(result, &CertChange{Field: f[0], Old: f[1], New: f[2]})
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ByIssuer returns certificates grouped by issuer
+func (r *SSLReport) ByIssuer() map[string][]*SSLReportItem {
+	if r == nil {
+		return nil
+	}
+
+	result := map[string][]*SSLReportItem{}
+
+	for _, item := range r.Certificates {
+		result[item.Issuer] = append(result[item.Issuer], item)
+	}
+
+	return result
+}
+
+// Weak returns certificates with weak signature algorithm
+func (r *SSLReport) Weak() []*SSLReportItem {
+	if r == nil {
+		return nil
+	}
+
+	var result []*SSLReportItem
+
+	for _, item := range r.Certificates {
+		if item.IsWeak {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// Expiring returns valid certificates which expire within given number of days
+func (r *SSLReport) Expiring(days int) []*SSLReportItem {
+	if r == nil {
+		return nil
+	}
+
+	var result []*SSLReportItem
+
+	for _, item := range r.Certificates {
+		if item.IsValid && !item.ValidTo.IsZero() && item.DaysRemaining <= days {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// Invalid returns invalid certificates (certificates which haven't been
+// tested yet are ignored)
+func (r *SSLReport) Invalid() []*SSLReportItem {
+	if r == nil {
+		return nil
+	}
+
+	var result []*SSLReportItem
+
+	for _, item := range r.Certificates {
+		if !item.IsValid && !item.TestedAt.IsZero() {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// WriteJSON writes report as JSON to given writer
+func (r *SSLReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// WriteCSV writes certificates as CSV to given writer
+func (r *SSLReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	cw.Write([]string{
+		"token", "alias", "url", "subject", "issuer", "algorithm", "valid_from",
+		"valid_to", "days_remaining", "valid", "weak", "error", "tested_at",
+	})
+
+	if r != nil {
+		for _, item := range r.Certificates {
+			cw.Write([]string{
+				item.Token, item.Alias, item.URL, item.Subject, item.Issuer,
+				item.Algorithm, formatReportDate(item.ValidFrom),
+				formatReportDate(item.ValidTo), strconv.Itoa(item.DaysRemaining),
+				strconv.FormatBool(item.IsValid), strconv.FormatBool(item.IsWeak),
+				item.Error, formatReportDate(item.TestedAt),
+			})
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newSSLReport creates SSL report
+func newSSLReport(checks Checks, events Webhook, now time.Time) *SSLReport {
+	report := &SSLReport{GeneratedAt: Date{now.UTC().Truncate(time.Second)}}
+	items := map[string]*SSLReportItem{}
+	certTimes := map[string]time.Time{}
+	fromChecks := map[string]bool{}
+
+	for _, c := range checks {
+		if c == nil || c.SSL == nil {
+			continue
+		}
+
+		item := &SSLReportItem{
+			Token:    c.Token,
+			Alias:    c.Alias,
+			URL:      c.URL,
+			ValidTo:  c.SSL.ExpiresAt,
+			IsValid:  c.SSL.IsValid,
+			Error:    c.SSL.Error,
+			TestedAt: c.SSL.TestedAt,
+		}
+
+		items[c.Token] = item
+		fromChecks[c.Token] = true
+		report.Certificates = append(report.Certificates, item)
+	}
+
+	for _, ev := range events {
+		if ev == nil {
+			continue
+		}
+
+		event, cert := getSSLEventCert(ev)
+
+		if event == nil || event.Check == nil {
+			continue
+		}
+
+		token := event.Check.Token
+		item := items[token]
+
+		if item == nil {
+			item = &SSLReportItem{
+				Token: token,
+				Alias: event.Check.Alias,
+				URL:   event.Check.URL,
+			}
+
+			items[token] = item
+			report.Certificates = append(report.Certificates, item)
+		}
+
+		if renew, ok := ev.Event.(*EventSSLRenewed); ok && renew.SSL != nil {
+			report.Renewals = append(report.Renewals, &SSLRenewal{
+				Token:   token,
+				Alias:   event.Check.Alias,
+				URL:     event.Check.URL,
+				Time:    event.Time,
+				OldCert: renew.SSL.OldCert,
+				NewCert: renew.SSL.NewCert,
+				Changes: DiffCerts(renew.SSL.OldCert, renew.SSL.NewCert),
+			})
+		}
+
+		// Use info from the latest event only. Events older than the last
+		// check test may contain info about previous certificate (e.g. if
+		// certificate was renewed after the event), so we use them only
+		// if certificate expiration date matches the current one.
+		switch {
+		case cert == nil,
+			event.Time.Before(certTimes[token]),
+			fromChecks[token] && !event.Time.After(item.TestedAt.Time) && !cert.To.Equal(item.ValidTo.Time):
+			continue
+		}
+
+		certTimes[token] = event.Time.Time
+
+		// Status of checks without current status is taken from events
+		if !fromChecks[token] {
+			item.IsValid = ev.Type != EVENT_SSL_INVALID
+			item.TestedAt = event.Time
+		}
+
+		item.Subject = cert.Subject
+		item.Issuer = cert.Issuer
+		item.Algorithm = cert.Algorithm
+		item.ValidFrom = cert.From
+
+		if !cert.To.IsZero() {
+			item.ValidTo = cert.To
+		}
+	}
+
+	for _, item := range report.Certificates {
+		item.IsWeak = IsWeakAlgorithm(item.Algorithm)
+		item.DaysRemaining = -1
+
+		if !item.ValidTo.IsZero() {
+			item.DaysRemaining = int(math.Floor(item.ValidTo.Sub(now).Hours() / 24))
+		}
+	}
+
+	slices.SortStableFunc(report.Certificates, func(a, b *SSLReportItem) int {
+		return cmp.Compare(a.DaysRemaining, b.DaysRemaining)
+	})
+
+	slices.SortStableFunc(report.Renewals, func(a, b *SSLRenewal) int {
+		return a.Time.Compare(b.Time.Time)
+	})
+
+	return report
+}
+
+// getSSLEventCert returns basic event info and certificate from SSL event
+func getSSLEventCert(ev *WebhookEvent) (*Event, *Cert) {
+	switch e := ev.Event.(type) {
+	case *EventSSLInvalid:
+		return &e.Event, getSSLCert(e.SSL)
+	case *EventSSLValid:
+		return &e.Event, getSSLCert(e.SSL)
+	case *EventSSLExpiration:
+		return &e.Event, getSSLCert(e.SSL)
+	case *EventSSLRenewed:
+		if e.SSL == nil {
+			return &e.Event, nil
+		}
+
+		return &e.Event, e.SSL.NewCert
+	}
+
+	return nil, nil
+}
+
+// getSSLCert returns certificate from SSL info
+func getSSLCert(ssl *SSL) *Cert {
+	if ssl == nil {
+		return nil
+	}
+
+	return ssl.Cert
+}
+
+// formatReportDate formats date for reports
+func formatReportDate(d Date) string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return d.UTC().Format(time.RFC3339)
+}
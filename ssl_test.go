@@ -0,0 +1,201 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestSSLReport(c *C) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	checks := Checks{
+		{
+			Token: "a001", Alias: "API", URL: "https://api.domain.com",
+			SSL: &SSLStatus{TestedAt: Date{now}, ExpiresAt: Date{now.Add(60 * day)}, IsValid: true},
+		},
+		{
+			Token: "a002", URL: "https://old.domain.com",
+			SSL: &SSLStatus{TestedAt: Date{now}, IsValid: false, Error: "certificate has expired"},
+		},
+		{Token: "a003", URL: "http://domain.com"},
+		{Token: "a005", URL: "https://new.domain.com", SSL: &SSLStatus{}},
+		nil,
+	}
+
+	oldCert := &Cert{
+		Subject: "api.domain.com", Issuer: "R3", Algorithm: "SHA-256 with RSA encryption",
+		From: Date{now.Add(-80 * day)}, To: Date{now.Add(10 * day)},
+	}
+
+	newCert := &Cert{
+		Subject: "api.domain.com", Issuer: "R11", Algorithm: "SHA-256 with RSA encryption",
+		From: Date{now.Add(-30 * day)}, To: Date{now.Add(60 * day)},
+	}
+
+	events := Webhook{
+		{Type: EVENT_SSL_EXPIRTAION, Event: &EventSSLExpiration{
+			Event: Event{Type: EVENT_SSL_EXPIRTAION, Time: Date{now.Add(-31 * day)}, Check: checks[0]},
+			SSL:   &SSL{Cert: oldCert, DaysBeforeExpiration: 30},
+		}},
+		{Type: EVENT_SSL_RENEWED, Event: &EventSSLRenewed{
+			Event: Event{Type: EVENT_SSL_RENEWED, Time: Date{now.Add(-30 * day)}, Check: checks[0]},
+			SSL:   &SSLRenew{OldCert: oldCert, NewCert: newCert},
+		}},
+		{Type: EVENT_SSL_VALID, Event: &EventSSLValid{
+			Event: Event{Type: EVENT_SSL_VALID, Time: Date{now.Add(-40 * day)}, Check: checks[0]},
+			SSL:   &SSL{Cert: oldCert},
+		}},
+		{Type: EVENT_SSL_INVALID, Event: &EventSSLInvalid{
+			Event: Event{Type: EVENT_SSL_INVALID, Time: Date{now.Add(-day)}, Check: &Check{Token: "a004", URL: "https://legacy.domain.com"}},
+			SSL: &SSL{Cert: &Cert{
+				Subject: "legacy.domain.com", Issuer: "Legacy CA", Algorithm: "SHA1 with RSA encryption",
+				From: Date{now.Add(-300 * day)}, To: Date{now.Add(5 * day)},
+			}},
+		}},
+		{Type: EVENT_DOWN, Event: &EventDown{Event: Event{Check: checks[0]}}},
+		{Type: EVENT_SSL_VALID, Event: &EventSSLValid{}},
+		nil,
+	}
+
+	report := newSSLReport(checks, events, now)
+
+	c.Assert(report.GeneratedAt.Equal(now), Equals, true)
+	c.Assert(report.Certificates, HasLen, 4)
+	c.Assert(report.Certificates[0].Token, Equals, "a002")
+	c.Assert(report.Certificates[0].DaysRemaining, Equals, -1)
+	c.Assert(report.Certificates[1].Token, Equals, "a005")
+	c.Assert(report.Certificates[1].DaysRemaining, Equals, -1)
+	c.Assert(report.Certificates[2].Token, Equals, "a004")
+	c.Assert(report.Certificates[2].IsValid, Equals, false)
+	c.Assert(report.Certificates[2].IsWeak, Equals, true)
+	c.Assert(report.Certificates[2].DaysRemaining, Equals, 5)
+
+	item := report.Certificates[3]
+
+	c.Assert(item.Token, Equals, "a001")
+	c.Assert(item.Issuer, Equals, "R11")
+	c.Assert(item.Subject, Equals, "api.domain.com")
+	c.Assert(item.ValidFrom.Equal(now.Add(-30*day)), Equals, true)
+	c.Assert(item.DaysRemaining, Equals, 60)
+	c.Assert(item.IsWeak, Equals, false)
+
+	c.Assert(report.Renewals, HasLen, 1)
+	c.Assert(report.Renewals[0].Token, Equals, "a001")
+	c.Assert(report.Renewals[0].Changes, HasLen, 3)
+	c.Assert(report.Renewals[0].Changes[0], DeepEquals, &CertChange{Field: "issuer", Old: "R3", New: "R11"})
+
+	c.Assert(report.ByIssuer()["R11"], HasLen, 1)
+	c.Assert(report.ByIssuer()[""], HasLen, 2)
+	c.Assert(report.Weak(), HasLen, 1)
+	c.Assert(report.Invalid(), HasLen, 2)
+	c.Assert(report.Invalid()[0].Token, Equals, "a002")
+	c.Assert(report.Invalid()[1].Token, Equals, "a004")
+	c.Assert(report.Expiring(30), HasLen, 0)
+	c.Assert(report.Expiring(90), HasLen, 1)
+
+	buf := &bytes.Buffer{}
+	c.Assert(report.WriteCSV(buf), IsNil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	c.Assert(lines, HasLen, 5)
+	c.Assert(lines[0], Equals, "token,alias,url,subject,issuer,algorithm,valid_from,valid_to,days_remaining,valid,weak,error,tested_at")
+	c.Assert(lines[1], Equals, "a002,,https://old.domain.com,,,,,,-1,false,false,certificate has expired,2025-03-01T12:00:00Z")
+	c.Assert(lines[4], Equals, "a001,API,https://api.domain.com,api.domain.com,R11,SHA-256 with RSA encryption,2025-01-30T12:00:00Z,2025-04-30T12:00:00Z,60,true,false,,2025-03-01T12:00:00Z")
+
+	buf.Reset()
+	c.Assert(report.WriteJSON(buf), IsNil)
+
+	decoded := &SSLReport{}
+	c.Assert(json.Unmarshal(buf.Bytes(), decoded), IsNil)
+	c.Assert(decoded.Certificates, HasLen, 4)
+	c.Assert(decoded.Renewals, HasLen, 1)
+
+	c.Assert(NewSSLReport(nil, nil).Certificates, HasLen, 0)
+
+	var nilReport *SSLReport
+
+	c.Assert(nilReport.ByIssuer(), IsNil)
+	c.Assert(nilReport.Weak(), IsNil)
+	c.Assert(nilReport.Invalid(), IsNil)
+	c.Assert(nilReport.Expiring(30), IsNil)
+
+	buf.Reset()
+	c.Assert(nilReport.WriteCSV(buf), IsNil)
+	c.Assert(strings.Count(buf.String(), "\n"), Equals, 1)
+}
+
+func (s *UpdownSuite) TestSSLReportRenewedCheck(c *C) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	checks := Checks{
+		{
+			Token: "a001", URL: "https://api.domain.com",
+			SSL: &SSLStatus{TestedAt: Date{now.Add(-day)}, ExpiresAt: Date{now.Add(90 * day)}, IsValid: true},
+		},
+		{
+			Token: "a002", URL: "https://www.domain.com",
+			SSL: &SSLStatus{TestedAt: Date{now.Add(-day)}, ExpiresAt: Date{now.Add(20 * day)}, IsValid: true},
+		},
+	}
+
+	events := Webhook{
+		// Certificate was renewed after this event
+		{Type: EVENT_SSL_EXPIRTAION, Event: &EventSSLExpiration{
+			Event: Event{Type: EVENT_SSL_EXPIRTAION, Time: Date{now.Add(-2 * day)}, Check: checks[0]},
+			SSL: &SSL{Cert: &Cert{
+				Subject: "api.domain.com", Issuer: "R3", Algorithm: "SHA1 with RSA encryption",
+				From: Date{now.Add(-85 * day)}, To: Date{now.Add(5 * day)},
+			}},
+		}},
+		{Type: EVENT_SSL_RENEWED, Event: &EventSSLRenewed{
+			Event: Event{Type: EVENT_SSL_RENEWED, Time: Date{now.Add(-2 * time.Hour)}, Check: checks[1]},
+			SSL: &SSLRenew{NewCert: &Cert{
+				Subject: "www.domain.com", Issuer: "R11",
+				From: Date{now.Add(-2 * time.Hour)}, To: Date{now.Add(88 * day)},
+			}},
+		}},
+	}
+
+	report := newSSLReport(checks, events, now)
+
+	c.Assert(report.Certificates, HasLen, 2)
+	c.Assert(report.Certificates[0].Token, Equals, "a002")
+	c.Assert(report.Certificates[0].DaysRemaining, Equals, 88)
+	c.Assert(report.Certificates[0].Issuer, Equals, "R11")
+	c.Assert(report.Certificates[1].Token, Equals, "a001")
+	c.Assert(report.Certificates[1].DaysRemaining, Equals, 90)
+	c.Assert(report.Certificates[1].Issuer, Equals, "")
+	c.Assert(report.Certificates[1].IsWeak, Equals, false)
+	c.Assert(report.Expiring(30), HasLen, 0)
+}
+
+func (s *UpdownSuite) TestSSLHelpers(c *C) {
+	c.Assert(IsWeakAlgorithm("SHA-256 with RSA encryption"), Equals, false)
+	c.Assert(IsWeakAlgorithm("ecdsa-with-SHA384"), Equals, false)
+	c.Assert(IsWeakAlgorithm("SHA-1 with RSA encryption"), Equals, true)
+	c.Assert(IsWeakAlgorithm("md5WithRSAEncryption"), Equals, true)
+
+	c.Assert(DiffCerts(nil, nil), HasLen, 0)
+	c.Assert(DiffCerts(nil, &Cert{Subject: "domain.com"}), DeepEquals, []*CertChange{
+		{Field: "subject", Old: "", New: "domain.com"},
+	})
+
+	ev, cert := getSSLEventCert(&WebhookEvent{Event: &EventSSLRenewed{}})
+	c.Assert(ev, NotNil)
+	c.Assert(cert, IsNil)
+}
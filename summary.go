@@ -0,0 +1,259 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_EXPIRATION_DAYS is default number of days used for detecting expiring
+// SSL certificates and domains
+const DEFAULT_EXPIRATION_DAYS = 14
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Summary contains aggregated info about checks health
+type Summary struct {
+	Total    int // Total number of checks
+	Up       int // Number of enabled checks which are up
+	Down     int // Number of enabled checks which are down
+	Disabled int // Number of disabled checks
+	Muted    int // Number of muted checks
+
+	InvalidSSL      Checks // Checks with invalid SSL certificate
+	ExpiringSSL     Checks // Checks with SSL certificate expiring soon
+	ExpiringDomains Checks // Checks with domain expiring soon
+
+	LowestUptime *Check // Enabled check with the lowest uptime
+	LowestApdex  *Check // Enabled check with the lowest Apdex (requires metrics)
+
+	// Days is number of days used for detecting expiring SSL certificates
+	// and domains
+	Days int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsMuted returns true if notifications for check are muted
+func (c *Check) IsMuted() bool {
+	return c != nil && c.MuteUntil.After(time.Now())
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Up returns enabled checks which are up
+func (c Checks) Up() Checks {
+	return c.filter(func(cc *Check) bool { return cc.IsEnabled && !cc.IsDown })
+}
+
+// Down returns enabled checks which are down
+func (c Checks) Down() Checks {
+	return c.filter(func(cc *Check) bool { return cc.IsEnabled && cc.IsDown })
+}
+
+// Disabled returns disabled checks
+func (c Checks) Disabled() Checks {
+	return c.filter(func(cc *Check) bool { return !cc.IsEnabled })
+}
+
+// Muted returns checks with muted notifications
+func (c Checks) Muted() Checks {
+	return c.filter(func(cc *Check) bool { return cc.IsMuted() })
+}
+
+// InvalidSSL returns checks with invalid SSL certificate
+func (c Checks) InvalidSSL() Checks {
+	return c.filter(isSSLInvalid)
+}
+
+// ExpiringSSL returns checks with valid SSL certificate which expires within
+// given number of days
+func (c Checks) ExpiringSSL(days int) Checks {
+	deadline := time.Now().AddDate(0, 0, days)
+
+	return c.filter(func(cc *Check) bool {
+		return isSSLExpiring(cc, deadline)
+	})
+}
+
+// ExpiringDomains returns checks with domain which expires within given number
+// of days
+func (c Checks) ExpiringDomains(days int) Checks {
+	return c.filter(func(cc *Check) bool {
+		return isDomainExpiring(cc, days)
+	})
+}
+
+// LowestUptime returns enabled check with the lowest uptime
+func (c Checks) LowestUptime() *Check {
+	var result *Check
+
+	for _, cc := range c {
+		if cc == nil || !cc.IsEnabled {
+			continue
+		}
+
+		if result == nil || cc.Uptime < result.Uptime {
+			result = cc
+		}
+	}
+
+	return result
+}
+
+// LowestApdex returns enabled check with the lowest Apdex. Only checks with
+// metrics are taken into account.
+func (c Checks) LowestApdex() *Check {
+	var result *Check
+
+	for _, cc := range c {
+		if cc == nil || !cc.IsEnabled || cc.Metrics == nil {
+			continue
+		}
+
+		if result == nil || cc.Metrics.Apdex < result.Metrics.Apdex {
+			result = cc
+		}
+	}
+
+	return result
+}
+
+// Summary returns aggregated info about checks health. Days is number of days
+// used for detecting expiring SSL certificates and domains (DEFAULT_EXPIRATION_DAYS
+// is used if days is 0 or less).
+func (c Checks) Summary(days int) *Summary {
+	return c.summarize(days, time.Now())
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsHealthy returns true if all enabled checks are up and there are no
+// problems with SSL certificates and domains
+func (s *Summary) IsHealthy() bool {
+	return s != nil && s.Down == 0 && len(s.InvalidSSL) == 0 &&
+		len(s.ExpiringSSL) == 0 && len(s.ExpiringDomains) == 0
+}
+
+// String returns short one-line summary (e.g. for CLI status line)
+func (s *Summary) String() string {
+	if s == nil {
+		return ""
+	}
+
+	result := fmt.Sprintf(
+		"%d checks: %d up, %d down, %d disabled, %d muted",
+		s.Total, s.Up, s.Down, s.Disabled, s.Muted,
+	)
+
+	var problems []string
+
+	if len(s.InvalidSSL) != 0 {
+		problems = append(problems, fmt.Sprintf("%d invalid SSL", len(s.InvalidSSL)))
+	}
+
+	if len(s.ExpiringSSL) != 0 {
+		problems = append(problems, fmt.Sprintf("%d SSL expiring", len(s.ExpiringSSL)))
+	}
+
+	if len(s.ExpiringDomains) != 0 {
+		problems = append(problems, fmt.Sprintf("%d domains expiring", len(s.ExpiringDomains)))
+	}
+
+	if len(problems) != 0 {
+		result += " (" + strings.Join(problems, ", ") + ")"
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// summarize creates checks summary
+func (c Checks) summarize(days int, now time.Time) *Summary {
+	if days <= 0 {
+		days = DEFAULT_EXPIRATION_DAYS
+	}
+
+	s := &Summary{Days: days}
+	deadline := now.AddDate(0, 0, days)
+
+	for _, cc := range c {
+		if cc == nil {
+			continue
+		}
+
+		s.Total++
+
+		switch {
+		case !cc.IsEnabled:
+			s.Disabled++
+		case cc.IsDown:
+			s.Down++
+		default:
+			s.Up++
+		}
+
+		if cc.MuteUntil.After(now) {
+			s.Muted++
+		}
+
+		if isSSLInvalid(cc) {
+			s.InvalidSSL = append(s.InvalidSSL, cc)
+		} else if isSSLExpiring(cc, deadline) {
+			s.ExpiringSSL = append(s.ExpiringSSL, cc)
+		}
+
+		if isDomainExpiring(cc, days) {
+			s.ExpiringDomains = append(s.ExpiringDomains, cc)
+		}
+	}
+
+	s.LowestUptime = c.LowestUptime()
+	s.LowestApdex = c.LowestApdex()
+
+	return s
+}
+
+// filter returns checks which match given function
+func (c Checks) filter(fn func(*Check) bool) Checks {
+	var result Checks
+
+	for _, cc := range c {
+		if cc != nil && fn(cc) {
+			result = append(result, cc)
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isSSLInvalid returns true if check has invalid SSL certificate
+func isSSLInvalid(c *Check) bool {
+	return c.SSL != nil && !c.SSL.TestedAt.IsZero() && !c.SSL.IsValid
+}
+
+// isSSLExpiring returns true if check has valid SSL certificate which expires
+// before given deadline
+func isSSLExpiring(c *Check, deadline time.Time) bool {
+	return c.SSL != nil && c.SSL.IsValid && !c.SSL.ExpiresAt.IsZero() &&
+		c.SSL.ExpiresAt.Before(deadline)
+}
+
+// isDomainExpiring returns true if check domain expires within given number
+// of days
+func isDomainExpiring(c *Check, days int) bool {
+	return c.Domain != nil && !c.Domain.ExpiresAt.IsZero() &&
+		c.Domain.RemainingDays <= days
+}
@@ -0,0 +1,94 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"time"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestSummary(c *C) {
+	now := time.Now()
+	day := 24 * time.Hour
+
+	checks := Checks{
+		{
+			Token: "a001", IsEnabled: true, Uptime: 99.9,
+			SSL:     &SSLStatus{TestedAt: Date{now}, ExpiresAt: Date{now.Add(90 * day)}, IsValid: true},
+			Domain:  &Domain{ExpiresAt: Date{now.Add(300 * day)}, RemainingDays: 300},
+			Metrics: &Metrics{Apdex: 0.9},
+		},
+		{
+			Token: "a002", IsEnabled: true, IsDown: true, Uptime: 95.5,
+			SSL:       &SSLStatus{TestedAt: Date{now}, ExpiresAt: Date{now.Add(5 * day)}, IsValid: true},
+			Domain:    &Domain{ExpiresAt: Date{now.Add(10 * day)}, RemainingDays: 10},
+			MuteUntil: Date{now.Add(time.Hour)},
+			Metrics:   &Metrics{Apdex: 0.7},
+		},
+		{
+			Token: "a003", IsEnabled: true, Uptime: 98,
+			SSL: &SSLStatus{TestedAt: Date{now}, IsValid: false, Error: "expired"},
+		},
+		{
+			Token: "a004", IsEnabled: false, Uptime: 10,
+			MuteUntil: Date{now.Add(-time.Hour)},
+			Metrics:   &Metrics{Apdex: 0.1},
+		},
+		nil,
+	}
+
+	c.Assert(checks.Up(), HasLen, 2)
+	c.Assert(checks.Down(), HasLen, 1)
+	c.Assert(checks.Disabled(), HasLen, 1)
+	c.Assert(checks.Muted(), HasLen, 1)
+	c.Assert(checks.InvalidSSL(), HasLen, 1)
+	c.Assert(checks.ExpiringSSL(7), HasLen, 1)
+	c.Assert(checks.ExpiringSSL(100), HasLen, 2)
+	c.Assert(checks.ExpiringDomains(7), HasLen, 0)
+	c.Assert(checks.ExpiringDomains(30), HasLen, 1)
+	c.Assert(checks.LowestUptime().Token, Equals, "a002")
+	c.Assert(checks.LowestApdex().Token, Equals, "a002")
+
+	sum := checks.Summary(0)
+
+	c.Assert(sum.Days, Equals, DEFAULT_EXPIRATION_DAYS)
+	c.Assert(sum.Total, Equals, 4)
+	c.Assert(sum.Up, Equals, 2)
+	c.Assert(sum.Down, Equals, 1)
+	c.Assert(sum.Disabled, Equals, 1)
+	c.Assert(sum.Muted, Equals, 1)
+	c.Assert(sum.InvalidSSL, HasLen, 1)
+	c.Assert(sum.InvalidSSL[0].Token, Equals, "a003")
+	c.Assert(sum.ExpiringSSL, HasLen, 1)
+	c.Assert(sum.ExpiringSSL[0].Token, Equals, "a002")
+	c.Assert(sum.ExpiringDomains, HasLen, 1)
+	c.Assert(sum.LowestUptime.Token, Equals, "a002")
+	c.Assert(sum.LowestApdex.Token, Equals, "a002")
+	c.Assert(sum.IsHealthy(), Equals, false)
+	c.Assert(sum.String(), Equals, "4 checks: 2 up, 1 down, 1 disabled, 1 muted (1 invalid SSL, 1 SSL expiring, 1 domains expiring)")
+
+	sum = checks[:1].Summary(30)
+
+	c.Assert(sum.IsHealthy(), Equals, true)
+	c.Assert(sum.String(), Equals, "1 checks: 1 up, 0 down, 0 disabled, 0 muted")
+
+	sum = Checks{}.Summary(30)
+
+	c.Assert(sum.LowestUptime, IsNil)
+	c.Assert(sum.LowestApdex, IsNil)
+
+	var nilSum *Summary
+	var nilCheck *Check
+
+	c.Assert(nilSum.IsHealthy(), Equals, false)
+	c.Assert(nilSum.String(), Equals, "")
+	c.Assert(nilCheck.IsMuted(), Equals, false)
+}
@@ -0,0 +1,28 @@
+module github.com/essentialkaos/updown/telemetry
+
+go 1.24.9
+
+require (
+	github.com/essentialkaos/check v1.4.1
+	github.com/essentialkaos/ek/v13 v13.36.1
+	github.com/essentialkaos/updown v0.3.0
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/metric v1.38.0
+	go.opentelemetry.io/otel/sdk v1.38.0
+	go.opentelemetry.io/otel/sdk/metric v1.38.0
+	go.opentelemetry.io/otel/trace v1.38.0
+	golang.org/x/net v0.43.0 // indirect
+)
+
+require (
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/kr/pretty v0.3.1 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/rogpeppe/go-internal v1.14.1 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	golang.org/x/sys v0.38.0 // indirect
+)
+
+replace github.com/essentialkaos/updown => ../
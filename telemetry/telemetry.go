@@ -0,0 +1,187 @@
+// Package telemetry provides OpenTelemetry tracing and metrics instrumentation
+// for updown.io API client
+package telemetry
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/essentialkaos/ek/v13/req"
+
+	"github.com/essentialkaos/updown"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SCOPE is instrumentation scope name
+const SCOPE = "github.com/essentialkaos/updown"
+
+const (
+	ATTR_METHOD      = attribute.Key("http.request.method")
+	ATTR_STATUS_CODE = attribute.Key("http.response.status_code")
+	ATTR_ENDPOINT    = attribute.Key("updown.endpoint")
+	ATTR_ROUTE       = attribute.Key("updown.route")
+	ATTR_TOKEN       = attribute.Key("updown.check.token")
+	ATTR_ATTEMPTS    = attribute.Key("updown.attempts")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Instrumentation is OpenTelemetry instrumentation for updown.io API client
+type Instrumentation struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	duration   metric.Float64Histogram
+	errors     metric.Int64Counter
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// New creates new instrumentation. If providers are nil, global providers will
+// be used. Trace context is propagated using global text map propagator.
+func New(tp trace.TracerProvider, mp metric.MeterProvider) (*Instrumentation, error) {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	meter := mp.Meter(SCOPE)
+
+	duration, err := meter.Float64Histogram(
+		"updown.client.request.duration",
+		metric.WithDescription("Duration of updown.io API requests"),
+		metric.WithUnit("s"),
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't create duration histogram: %w", err)
+	}
+
+	errors, err := meter.Int64Counter(
+		"updown.client.request.errors",
+		metric.WithDescription("Number of failed updown.io API requests"),
+		metric.WithUnit("{request}"),
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't create errors counter: %w", err)
+	}
+
+	return &Instrumentation{
+		tracer:     tp.Tracer(SCOPE),
+		propagator: otel.GetTextMapPropagator(),
+		duration:   duration,
+		errors:     errors,
+	}, nil
+}
+
+// Instrument creates instrumentation using global providers and enables it for
+// given client and pulse requests
+func Instrument(client *updown.Client) error {
+	i, err := New(nil, nil)
+
+	if err != nil {
+		return err
+	}
+
+	client.SetInstrumentation(i)
+	updown.SetPulseInstrumentation(i)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RequestStart starts client span for request as a child of given context and
+// injects trace context into request headers
+func (i *Instrumentation) RequestStart(ctx context.Context, info *updown.RequestInfo, headers req.Headers) context.Context {
+	if i == nil || info == nil {
+		return ctx
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	attrs := []attribute.KeyValue{
+		ATTR_METHOD.String(info.Method),
+		ATTR_ROUTE.String(info.Route),
+		ATTR_ENDPOINT.String(info.Endpoint),
+	}
+
+	if info.Token != "" {
+		attrs = append(attrs, ATTR_TOKEN.String(info.Token))
+	}
+
+	ctx, _ = i.tracer.Start(
+		ctx, "updown "+info.Method+" "+info.Route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	if headers != nil {
+		i.propagator.Inject(ctx, propagation.MapCarrier(headers))
+	}
+
+	return ctx
+}
+
+// RequestDone ends span started by RequestStart and records metrics for
+// finished request
+func (i *Instrumentation) RequestDone(info *updown.RequestInfo) {
+	if i == nil || info == nil {
+		return
+	}
+
+	ctx := info.Context
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	attrs := []attribute.KeyValue{
+		ATTR_METHOD.String(info.Method),
+		ATTR_ROUTE.String(info.Route),
+	}
+
+	if info.StatusCode != 0 {
+		attrs = append(attrs, ATTR_STATUS_CODE.Int(info.StatusCode))
+	}
+
+	span := trace.SpanFromContext(ctx)
+
+	if info.Attempts != 0 {
+		span.SetAttributes(ATTR_ATTEMPTS.Int(info.Attempts))
+	}
+
+	if info.StatusCode != 0 {
+		span.SetAttributes(ATTR_STATUS_CODE.Int(info.StatusCode))
+	}
+
+	if info.Error != nil {
+		span.RecordError(info.Error)
+		span.SetStatus(codes.Error, info.Error.Error())
+		i.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
+	span.End()
+
+	i.duration.Record(ctx, info.Duration.Seconds(), metric.WithAttributes(attrs...))
+}
@@ -0,0 +1,141 @@
+package telemetry
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/essentialkaos/ek/v13/req"
+
+	"github.com/essentialkaos/updown"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type TelemetrySuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&TelemetrySuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *TelemetrySuite) TestInstrument(c *C) {
+	client, _ := updown.NewClient("test1234")
+
+	c.Assert(Instrument(client), IsNil)
+
+	updown.SetPulseInstrumentation(nil)
+
+	i, err := New(nil, nil)
+
+	c.Assert(err, IsNil)
+	c.Assert(i, NotNil)
+
+	var ni *Instrumentation
+	ni.RequestDone(&updown.RequestInfo{})
+	i.RequestDone(nil)
+
+	c.Assert(ni.RequestStart(context.Background(), &updown.RequestInfo{}, nil), NotNil)
+	c.Assert(i.RequestStart(context.Background(), nil, nil), NotNil)
+}
+
+func (s *TelemetrySuite) TestRequestDone(c *C) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	i, err := New(tp, mp)
+
+	c.Assert(err, IsNil)
+
+	parentCtx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+
+	info1 := &updown.RequestInfo{
+		Method:   "GET",
+		Endpoint: "/checks/ngg8/metrics",
+		Route:    "/checks/:token/metrics",
+		Token:    "ngg8",
+		Start:    time.Now(),
+	}
+
+	headers := req.Headers{}
+	info1.Context = i.RequestStart(parentCtx, info1, headers)
+
+	c.Assert(headers["traceparent"], Not(Equals), "")
+	c.Assert(recorder.Ended(), HasLen, 0)
+
+	info1.StatusCode, info1.Attempts = 200, 1
+	info1.Duration = time.Since(info1.Start)
+
+	i.RequestDone(info1)
+
+	info2 := &updown.RequestInfo{
+		Method:   "GET",
+		Endpoint: "/checks",
+		Route:    "/checks",
+		Start:    time.Now(),
+	}
+
+	info2.Context = i.RequestStart(nil, info2, nil)
+	info2.Attempts, info2.Error = 1, errors.New("Can't send request")
+	info2.Duration = time.Since(info2.Start)
+
+	i.RequestDone(info2)
+
+	parent.End()
+
+	spans := recorder.Ended()
+
+	c.Assert(spans, HasLen, 3)
+	c.Assert(spans[0].Name(), Equals, "updown GET /checks/:token/metrics")
+	c.Assert(spans[0].SpanKind(), Equals, trace.SpanKindClient)
+	c.Assert(spans[0].Parent().SpanID(), Equals, parent.SpanContext().SpanID())
+	c.Assert(spans[0].Parent().TraceID(), Equals, parent.SpanContext().TraceID())
+	c.Assert(headers["traceparent"], Matches, ".*"+spans[0].SpanContext().SpanID().String()+".*")
+	c.Assert(spans[0].Status().Code, Equals, codes.Unset)
+	c.Assert(spans[0].Attributes(), HasLen, 6)
+	c.Assert(spans[1].Name(), Equals, "updown GET /checks")
+	c.Assert(spans[1].Parent().IsValid(), Equals, false)
+	c.Assert(spans[1].Status().Code, Equals, codes.Error)
+	c.Assert(spans[1].Status().Description, Equals, "Can't send request")
+
+	rm := metricdata.ResourceMetrics{}
+
+	c.Assert(reader.Collect(context.Background(), &rm), IsNil)
+	c.Assert(rm.ScopeMetrics, HasLen, 1)
+
+	ms := rm.ScopeMetrics[0].Metrics
+
+	c.Assert(ms, HasLen, 2)
+	c.Assert(ms[0].Name, Equals, "updown.client.request.duration")
+	c.Assert(ms[0].Data.(metricdata.Histogram[float64]).DataPoints, HasLen, 2)
+	c.Assert(ms[1].Name, Equals, "updown.client.request.errors")
+	c.Assert(ms[1].Data.(metricdata.Sum[int64]).DataPoints, HasLen, 1)
+	c.Assert(ms[1].Data.(metricdata.Sum[int64]).DataPoints[0].Value, Equals, int64(1))
+}
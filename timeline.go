@@ -0,0 +1,349 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Timeline is incident timeline reconstructed from downtime results
+type Timeline struct {
+	Downtime *Downtime
+	Entries  []*TimelineEntry
+
+	FirstFailure  *TimelineEntry
+	LastFailure   *TimelineEntry
+	FirstRecovery *TimelineEntry
+
+	nodes Nodes
+}
+
+// TimelineEntry contains info about single probe result
+type TimelineEntry struct {
+	Time       time.Time
+	Node       string
+	IsUp       bool
+	Code       int
+	IP         string
+	FinalURL   string
+	Error      string
+	DetailsURL string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewTimeline creates incident timeline from downtime results. Downtime must
+// contain results (see detailed flag of Client.GetDowntimes). Nodes are used
+// for resolving nodes locations and can be nil.
+func NewTimeline(d *Downtime, nodes Nodes) *Timeline {
+	t := &Timeline{Downtime: d, nodes: nodes}
+
+	if d == nil {
+		return t
+	}
+
+	for _, r := range d.DownResults {
+		if e := newTimelineEntry(r, false, d.Error); e != nil {
+			t.Entries = append(t.Entries, e)
+		}
+	}
+
+	for _, r := range d.UpResults {
+		if e := newTimelineEntry(r, true, ""); e != nil {
+			t.Entries = append(t.Entries, e)
+		}
+	}
+
+	slices.SortStableFunc(t.Entries, func(a, b *TimelineEntry) int {
+		return cmp.Or(a.Time.Compare(b.Time), cmp.Compare(a.Node, b.Node))
+	})
+
+	for _, e := range t.Entries {
+		if !e.IsUp {
+			if t.FirstFailure == nil {
+				t.FirstFailure = e
+			}
+
+			t.LastFailure = e
+		}
+	}
+
+	for _, e := range t.Entries {
+		if e.IsUp && t.FirstFailure != nil && !e.Time.Before(t.FirstFailure.Time) {
+			t.FirstRecovery = e
+			break
+		}
+	}
+
+	return t
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// FailedNodes returns sorted slice with names of nodes which reported failure
+func (t *Timeline) FailedNodes() []string {
+	if t == nil {
+		return nil
+	}
+
+	var result []string
+
+	for _, e := range t.Entries {
+		if !e.IsUp && e.Node != "" && !slices.Contains(result, e.Node) {
+			result = append(result, e.Node)
+		}
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// ByNode returns timeline entries grouped by node
+func (t *Timeline) ByNode() map[string][]*TimelineEntry {
+	if t == nil {
+		return nil
+	}
+
+	result := map[string][]*TimelineEntry{}
+
+	for _, e := range t.Entries {
+		result[e.Node] = append(result[e.Node], e)
+	}
+
+	return result
+}
+
+// Duration returns duration between first failure and first recovery. If
+// there is no recovery in results, duration of downtime is returned.
+func (t *Timeline) Duration() time.Duration {
+	switch {
+	case t == nil:
+		return 0
+	case t.FirstFailure != nil && t.FirstRecovery != nil:
+		return t.FirstRecovery.Time.Sub(t.FirstFailure.Time)
+	case t.Downtime != nil:
+		return time.Duration(t.Downtime.Duration) * time.Second
+	}
+
+	return 0
+}
+
+// WriteText writes human-readable timeline to given writer
+func (t *Timeline) WriteText(w io.Writer) error {
+	if t == nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, t.title())
+	fmt.Fprintln(&buf)
+
+	for _, line := range t.summary() {
+		fmt.Fprintf(&buf, "%s: %s\n", line[0], line[1])
+	}
+
+	if len(t.Entries) != 0 {
+		fmt.Fprintln(&buf)
+
+		tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+		for _, e := range t.Entries {
+			fmt.Fprintln(tw, strings.Join(t.formatEntry(e), "\t"))
+		}
+
+		tw.Flush()
+	}
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// WriteMarkdown writes timeline as Markdown postmortem section to given writer
+func (t *Timeline) WriteMarkdown(w io.Writer) error {
+	if t == nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "### %s\n\n", t.title())
+
+	for _, line := range t.summary() {
+		fmt.Fprintf(&buf, "- **%s:** %s\n", line[0], escapeMarkdown(line[1]))
+	}
+
+	if len(t.Entries) != 0 {
+		buf.WriteString("\n| Time | Status | Node | Code | IP | Final URL | Error |\n")
+		buf.WriteString("|------|--------|------|------|----|-----------|-------|\n")
+
+		for _, e := range t.Entries {
+			fields := t.formatEntry(e)
+
+			for i := range fields {
+				fields[i] = escapeMarkdown(fields[i])
+			}
+
+			fmt.Fprintf(&buf, "| %s |\n", strings.Join(fields, " | "))
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// String returns human-readable timeline
+func (t *Timeline) String() string {
+	var buf bytes.Buffer
+
+	t.WriteText(&buf)
+
+	return buf.String()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// title returns timeline title
+func (t *Timeline) title() string {
+	d := t.Downtime
+
+	if d == nil {
+		return "Incident"
+	}
+
+	if d.EndedAt.IsZero() {
+		return fmt.Sprintf("Incident %s (ongoing)", formatReportDate(d.StartedAt))
+	}
+
+	return fmt.Sprintf(
+		"Incident %s – %s (%s)",
+		formatReportDate(d.StartedAt), formatReportDate(d.EndedAt), t.Duration(),
+	)
+}
+
+// summary returns timeline summary as slice of name/value pairs
+func (t *Timeline) summary() [][2]string {
+	var result [][2]string
+
+	if t.Downtime != nil && t.Downtime.Error != "" {
+		result = append(result, [2]string{"Error", t.Downtime.Error})
+	}
+
+	if failed := t.FailedNodes(); len(failed) != 0 {
+		info := "Failed from " + t.nodes.Describe(failed...)
+
+		if active := len(t.ByNode()); len(failed) < active {
+			info += " only"
+		}
+
+		result = append(result, [2]string{"Locations", info})
+	}
+
+	for _, e := range []struct {
+		name  string
+		entry *TimelineEntry
+	}{
+		{"First failure", t.FirstFailure},
+		{"Last failure", t.LastFailure},
+		{"First recovery", t.FirstRecovery},
+	} {
+		if e.entry != nil {
+			result = append(result, [2]string{
+				e.name,
+				e.entry.Time.UTC().Format(time.RFC3339) + " from " + t.nodeName(e.entry.Node),
+			})
+		}
+	}
+
+	return result
+}
+
+// formatEntry returns timeline entry fields
+func (t *Timeline) formatEntry(e *TimelineEntry) []string {
+	status, code := "DOWN", "-"
+
+	if e.IsUp {
+		status = "UP"
+	}
+
+	if e.Code != 0 {
+		code = strconv.Itoa(e.Code)
+	}
+
+	return []string{
+		e.Time.UTC().Format(time.RFC3339), status, t.nodeName(e.Node), code,
+		cmp.Or(e.IP, "-"), cmp.Or(e.FinalURL, "-"), cmp.Or(e.Error, "-"),
+	}
+}
+
+// nodeName returns node name with location
+func (t *Timeline) nodeName(name string) string {
+	if loc := t.nodes[name].Location(); loc != "" {
+		return name + " (" + loc + ")"
+	}
+
+	return name
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newTimelineEntry creates new timeline entry from downtime result
+func newTimelineEntry(r *DowntimeCheck, isUp bool, errMsg string) *TimelineEntry {
+	if r == nil {
+		return nil
+	}
+
+	e := &TimelineEntry{IsUp: isUp, DetailsURL: r.DetailsURL}
+
+	if r.Request != nil {
+		e.Time = r.Request.SentAt.Time
+		e.Node = r.Request.Node
+	}
+
+	if r.Response != nil {
+		if e.Time.IsZero() {
+			e.Time = r.Response.ReceivedAt.Time
+		}
+
+		e.Code = r.Response.Code
+		e.IP = r.Response.IP
+		e.FinalURL = r.Response.FinalURL
+	}
+
+	if !isUp {
+		// Status of failed probe usually contains error description
+		switch strings.ToLower(r.Status) {
+		case "", "up", "down":
+			e.Error = errMsg
+		default:
+			e.Error = r.Status
+		}
+	}
+
+	return e
+}
+
+// escapeMarkdown escapes symbols which break Markdown tables and formatting
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer(
+		"|", "\\|", "*", "\\*", "_", "\\_", "`", "\\`", "\n", " ",
+	).Replace(s)
+}
@@ -0,0 +1,101 @@
+package updown
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdownSuite) TestTimeline(c *C) {
+	ts := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	probe := func(node string, offset time.Duration, code int, status string) *DowntimeCheck {
+		return &DowntimeCheck{
+			Status:   status,
+			Request:  &DowntimeRequest{Node: node, SentAt: Date{ts.Add(offset)}},
+			Response: &DowntimeResponse{Code: code, IP: "1.2.3.4", FinalURL: "https://example.com/a_b"},
+		}
+	}
+
+	d := &Downtime{
+		Error:     "500 Internal Server Error",
+		StartedAt: Date{ts},
+		EndedAt:   Date{ts.Add(5 * time.Minute)},
+		Duration:  300,
+		DownResults: []*DowntimeCheck{
+			probe("tok", time.Minute, 500, "down"),
+			probe("syd", 0, 0, "Connection timeout"),
+			nil,
+		},
+		UpResults: []*DowntimeCheck{
+			probe("fra", -time.Minute, 200, "up"),
+			probe("tok", 4*time.Minute, 200, "up"),
+			{Response: &DowntimeResponse{ReceivedAt: Date{ts.Add(6 * time.Minute)}, Code: 200}},
+		},
+	}
+
+	t := NewTimeline(d, testNodes)
+
+	c.Assert(t.Entries, HasLen, 5)
+	c.Assert(t.Entries[0].Node, Equals, "fra")
+	c.Assert(t.Entries[1].Node, Equals, "syd")
+	c.Assert(t.Entries[1].Error, Equals, "Connection timeout")
+	c.Assert(t.Entries[2].Error, Equals, "500 Internal Server Error")
+	c.Assert(t.Entries[4].Time, Equals, ts.Add(6*time.Minute))
+	c.Assert(t.FirstFailure.Node, Equals, "syd")
+	c.Assert(t.LastFailure.Node, Equals, "tok")
+	c.Assert(t.FirstRecovery.Time, Equals, ts.Add(4*time.Minute))
+	c.Assert(t.Duration(), Equals, 4*time.Minute)
+	c.Assert(t.FailedNodes(), DeepEquals, []string{"syd", "tok"})
+	c.Assert(t.ByNode()["tok"], HasLen, 2)
+
+	text := t.String()
+
+	c.Assert(text, Matches, `(?s)Incident 2025-03-01T10:00:00Z – 2025-03-01T10:05:00Z \(4m0s\).*`)
+	c.Assert(strings.Contains(text, "Locations: Failed from Sydney and Tokyo only"), Equals, true)
+	c.Assert(strings.Contains(text, "First failure: 2025-03-01T10:00:00Z from syd (Sydney, Australia)"), Equals, true)
+	c.Assert(strings.Contains(text, "First recovery: 2025-03-01T10:04:00Z from tok (Tokyo, Japan)"), Equals, true)
+
+	var buf bytes.Buffer
+
+	c.Assert(t.WriteMarkdown(&buf), IsNil)
+
+	md := buf.String()
+
+	c.Assert(strings.HasPrefix(md, "### Incident"), Equals, true)
+	c.Assert(strings.Contains(md, "- **Error:** 500 Internal Server Error"), Equals, true)
+	c.Assert(strings.Contains(md, "| 2025-03-01T10:01:00Z | DOWN | tok (Tokyo, Japan) | 500 | 1.2.3.4 | https://example.com/a\\_b |"), Equals, true)
+
+	d.EndedAt = Date{}
+	d.UpResults = nil
+	t = NewTimeline(d, nil)
+
+	c.Assert(t.FirstRecovery, IsNil)
+	c.Assert(t.Duration(), Equals, 5*time.Minute)
+	c.Assert(t.String(), Matches, `(?s)Incident 2025-03-01T10:00:00Z \(ongoing\).*Failed from syd and tok\n.*`)
+
+	t = NewTimeline(nil, nil)
+
+	c.Assert(t.Entries, HasLen, 0)
+	c.Assert(t.Duration(), Equals, time.Duration(0))
+	c.Assert(t.String(), Equals, "Incident\n\n")
+
+	var nilTimeline *Timeline
+
+	c.Assert(nilTimeline.FailedNodes(), IsNil)
+	c.Assert(nilTimeline.ByNode(), IsNil)
+	c.Assert(nilTimeline.Duration(), Equals, time.Duration(0))
+	c.Assert(nilTimeline.WriteText(&buf), IsNil)
+	c.Assert(nilTimeline.WriteMarkdown(&buf), IsNil)
+}
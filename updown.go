@@ -8,14 +8,19 @@ package updown
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/essentialkaos/ek/v13/req"
+	"github.com/essentialkaos/ek/v13/timeutil"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -35,6 +40,19 @@ const (
 	SOURCE_RDAP  = "RDAP"
 )
 
+const (
+	GROUP_BY_TIME MetricsGroup = "time"
+	GROUP_BY_HOST MetricsGroup = "host"
+)
+
+// METRICS_MAX_RANGE is default maximum range of single metrics request. Metrics
+// for longer ranges are fetched using multiple requests. The API documentation
+// doesn't specify the limit, so it matches the default metrics period (one month)
+// and can be changed using Client.SetMetricsMaxRange.
+//
+// https://updown.io/api#GET-/api/checks/:token/metrics
+const METRICS_MAX_RANGE = 31 * 24 * time.Hour
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // Date is JSON date
@@ -244,6 +262,25 @@ type Metrics struct {
 	Requests *RequestStats `json:"requests"`
 }
 
+// MetricsSeries is a slice with metrics grouped by time (hour)
+type MetricsSeries []*MetricsPoint
+
+// MetricsPoint contains metrics for specific hour
+type MetricsPoint struct {
+	Time    time.Time
+	Metrics *Metrics
+}
+
+// HostsMetrics is a map with metrics grouped by monitoring node name
+type HostsMetrics map[string]*Metrics
+
+// NodeMetrics contains metrics for specific monitoring node
+type NodeMetrics struct {
+	Name    string
+	Node    *Node
+	Metrics *Metrics
+}
+
 // Domain contains info about domain
 type Domain struct {
 	TestedAt      Date   `json:"tested_at"`
@@ -358,11 +395,34 @@ type PerformanceApdex struct {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// MetricsGroup is metrics grouping type
+type MetricsGroup string
+
 // MetricsOptions is options for metrics request
 type MetricsOptions struct {
 	From    time.Time
 	To      time.Time
-	GroupBy string
+	GroupBy MetricsGroup
+}
+
+// CheckOptions contains check parameters for creating or updating check. Empty
+// fields are not sent to API, so they stay unchanged on update.
+//
+// https://updown.io/api#POST-/api/checks
+type CheckOptions struct {
+	URL               string            `json:"url,omitempty"`
+	Alias             string            `json:"alias,omitempty"`
+	Period            int               `json:"period,omitempty"`
+	Apdex             float64           `json:"apdex_t,omitempty"`
+	IsEnabled         *bool             `json:"enabled,omitempty"`
+	IsPublished       *bool             `json:"published,omitempty"`
+	MuteUntil         *string           `json:"mute_until,omitempty"`
+	StringMatch       string            `json:"string_match,omitempty"`
+	HTTPVerb          string            `json:"http_verb,omitempty"`
+	HTTPBody          string            `json:"http_body,omitempty"`
+	Recipients        []string          `json:"recipients,omitempty"`
+	DisabledLocations []string          `json:"disabled_locations,omitempty"`
+	CustomHeaders     map[string]string `json:"custom_headers,omitempty"`
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -380,13 +440,85 @@ type apdexInfo struct {
 	Apdex float64 `json:"apdex"`
 }
 
+// statusError is error for API response with non-ok status code
+type statusError struct {
+	code       int
+	retryAfter time.Duration // Delay from Retry-After header (-1 if not set)
+}
+
+// deleteResponse is response for delete request
+type deleteResponse struct {
+	IsDeleted bool `json:"deleted"`
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // Client is Updown API client
 type Client struct {
-	engine *req.Engine
-	apiKey string
-	calls  uint
+	engine          *req.Engine
+	apiKey          string
+	url             string
+	calls           atomic.Uint64
+	instrumentation Instrumentation
+	middlewares     []Middleware
+	cache           atomic.Pointer[responseCache]
+	limiter         atomic.Pointer[rateLimiter]
+	concurrency     atomic.Int64
+	metricsRange    atomic.Int64
+}
+
+// APIRequest contains info about API request
+type APIRequest struct {
+	Method   string      // HTTP method
+	Endpoint string      // API endpoint (e.g. /checks)
+	Query    req.Query   // Request query
+	Headers  req.Headers // Request headers
+	Body     any         // Request body
+}
+
+// APIResponse contains API response data
+type APIResponse struct {
+	StatusCode int         // HTTP status code
+	Header     http.Header // Response headers
+	Body       []byte      // Response body
+}
+
+// RoundTrip is function which sends request to API and returns response
+type RoundTrip func(r *APIRequest) (*APIResponse, error)
+
+// Middleware is function which wraps round trip for observing or modifying
+// requests and responses
+type Middleware func(next RoundTrip) RoundTrip
+
+// Instrumentation is interface for API requests instrumentation
+type Instrumentation interface {
+	// RequestDone is called after every request with info about it
+	RequestDone(info *RequestInfo)
+}
+
+// RequestInfo contains info about finished request
+type RequestInfo struct {
+	Method     string        // HTTP method
+	Endpoint   string        // API endpoint or pulse host
+	Route      string        // API endpoint with token placeholder
+	Token      string        // Check token
+	StatusCode int           // HTTP status code (0 if request wasn't sent)
+	Attempts   int           // Number of attempts (pulse requests only)
+	Start      time.Time     // Request start date
+	Duration   time.Duration // Request duration (including all attempts)
+	Error      error         // Request error
+
+	// Context is request context returned by RequestTracer (or parent context
+	// if instrumentation doesn't support tracing)
+	Context context.Context
+}
+
+// RequestTracer is optional interface for instrumentation which traces requests.
+// RequestStart is called before request is sent with parent context and
+// request headers, so it can start span and inject propagation headers. Returned
+// context is passed to RequestDone as a part of request info.
+type RequestTracer interface {
+	RequestStart(ctx context.Context, info *RequestInfo, headers req.Headers) context.Context
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -396,6 +528,14 @@ var (
 	ErrNilClient     = errors.New("Client is nil")
 	ErrEmptyToken    = errors.New("Token is empty")
 	ErrEmptyPulseURL = errors.New("Pulse URL is empty")
+	ErrEmptyURL      = errors.New("Check URL is empty")
+	ErrNotDeleted    = errors.New("API didn't confirm check deletion")
+	ErrNilResponse   = errors.New("Round trip returned nil response")
+	ErrEmptyMuteDate = errors.New("Mute end date is empty")
+
+	ErrInvalidMetricsRange = errors.New("Metrics range end date is before start date")
+	ErrFutureMetricsRange  = errors.New("Metrics range start date is in the future")
+	ErrInvalidMetricsGroup = errors.New("Unknown metrics group")
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -406,6 +546,9 @@ var apiURL = "https://updown.io/api"
 // defaultHeaders is a collection of default request headers
 var defaultHeaders = req.Headers{"Accept-Encoding": "gzip"}
 
+// pulseInstrumentation is instrumentation used for pulse requests
+var pulseInstrumentation atomic.Pointer[Instrumentation]
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // ParseWebhook parses webhook data
@@ -470,42 +613,47 @@ func NewClient(apiKey string) (*Client, error) {
 	return c, nil
 }
 
-// SendPulse sends "pulse" request to updown and returns request UUID
+// SendPulse sends "pulse" request to updown and returns request UUID. It uses
+// pulse client with default configuration, use PulseClient for customization.
 //
 // https://updown.io/doc/how-pulse-cron-monitoring-works
 func SendPulse(url, payload string) (string, error) {
-	if url == "" {
-		return "", ErrEmptyPulseURL
-	}
-
-	rr := req.NewRetrier()
-	r := req.Request{URL: url}
+	result, err := NewPulseClient().Send(url, payload)
 
-	if payload != "" {
-		r.Method = req.POST
-		r.Body = payload
+	if err != nil {
+		return "", err
 	}
 
-	resp, err := rr.Do(r, req.Retry{Num: 5, Pause: time.Second / 4, Status: 200})
+	return result.UUID, nil
+}
 
-	if err != nil {
-		return "", fmt.Errorf("Can't send pulse request: %v", err)
+// SetPulseInstrumentation sets instrumentation for pulse requests
+func SetPulseInstrumentation(i Instrumentation) {
+	if i == nil {
+		pulseInstrumentation.Store(nil)
+		return
 	}
 
-	_, uuid, _ := strings.Cut(resp.String(), " ")
+	pulseInstrumentation.Store(&i)
+}
 
-	return uuid, nil
+// MetricsRange creates metrics options for relative range. Supported ranges are
+// "last <duration>" (e.g. "last 24h" or "last 1w2d"), "today", "yesterday",
+// "this week", "last week", "this month" and "last month".
+func MetricsRange(rng string) (MetricsOptions, error) {
+	return parseMetricsRange(rng, time.Now())
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// Calls returns total number of API calls made by the client
+// Calls returns total number of API calls made by the client. Responses served
+// from cache are not counted.
 func (c *Client) Calls() uint {
 	if c == nil {
 		return 0
 	}
 
-	return c.calls
+	return uint(c.calls.Load())
 }
 
 // SetUserAgent sets client user agent
@@ -521,6 +669,57 @@ func (c *Client) SetUserAgent(app, version string) {
 	}
 }
 
+// SetURL sets custom API URL (e.g. URL of mock server). Empty URL resets it
+// to default.
+func (c *Client) SetURL(url string) {
+	if c == nil {
+		return
+	}
+
+	c.url = strings.TrimRight(url, "/")
+}
+
+// SetHTTPClient sets custom HTTP client used for API requests (e.g. client with
+// custom transport)
+func (c *Client) SetHTTPClient(client *http.Client) {
+	if c == nil || c.engine == nil || client == nil {
+		return
+	}
+
+	c.engine.Client = client
+}
+
+// SetInstrumentation sets instrumentation for API requests (e.g. OpenTelemetry
+// tracing and metrics)
+func (c *Client) SetInstrumentation(i Instrumentation) {
+	if c == nil {
+		return
+	}
+
+	c.instrumentation = i
+}
+
+// SetMetricsMaxRange sets maximum range of single metrics request. Metrics for
+// longer ranges are fetched using multiple requests. If range is 0 or less,
+// METRICS_MAX_RANGE is used.
+func (c *Client) SetMetricsMaxRange(d time.Duration) {
+	if c == nil {
+		return
+	}
+
+	c.metricsRange.Store(int64(max(d, 0)))
+}
+
+// Use adds middleware to the client. Middlewares are called in the order they
+// were added, so the first added middleware will receive request first.
+func (c *Client) Use(mw Middleware) {
+	if c == nil || mw == nil {
+		return
+	}
+
+	c.middlewares = append(c.middlewares, mw)
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // Link returns URL of check info page
@@ -532,17 +731,15 @@ func (c *Check) Link() string {
 	return "https://updown.io/" + c.Token
 }
 
-// Get returns check with given token or alias
+// Get returns check with given token or alias. Use Index for multiple lookups.
 func (c Checks) Get(tokenOrAlias string) *Check {
 	if tokenOrAlias == "" {
 		return nil
 	}
 
-	tokenOrAlias = strings.ToLower(tokenOrAlias)
-
 	for _, cc := range c {
-		if strings.ToLower(cc.Token) == tokenOrAlias ||
-			(cc.Alias != "" && strings.ToLower(cc.Alias) == tokenOrAlias) {
+		if cc != nil && (strings.EqualFold(cc.Token, tokenOrAlias) ||
+			(cc.Alias != "" && strings.EqualFold(cc.Alias, tokenOrAlias))) {
 			return cc
 		}
 	}
@@ -552,16 +749,58 @@ func (c Checks) Get(tokenOrAlias string) *Check {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// Get returns metrics for given hour
+func (s MetricsSeries) Get(t time.Time) *Metrics {
+	t = t.Truncate(time.Hour)
+
+	for _, p := range s {
+		if p.Time.Equal(t) {
+			return p.Metrics
+		}
+	}
+
+	return nil
+}
+
+// Join joins metrics with info about monitoring nodes. Result is sorted by node
+// name.
+func (m HostsMetrics) Join(nodes Nodes) []*NodeMetrics {
+	var result []*NodeMetrics
+
+	for name, metrics := range m {
+		result = append(result, &NodeMetrics{
+			Name:    name,
+			Node:    nodes[name],
+			Metrics: metrics,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // GetChecks returns info about all checks
 //
 // https://updown.io/api#GET-/api/checks
 func (c *Client) GetChecks() (Checks, error) {
+	return c.GetChecksContext(context.Background())
+}
+
+// GetChecksContext returns info about all checks using given context
+//
+// https://updown.io/api#GET-/api/checks
+func (c *Client) GetChecksContext(ctx context.Context) (Checks, error) {
 	if c == nil || c.engine == nil {
 		return nil, ErrNilClient
 	}
 
 	result := Checks{}
-	err := c.sendRequest(req.GET, "/checks", &result, nil, nil)
+	err := c.sendRequest(ctx, req.GET, "/checks", &result, nil, nil)
 
 	if err != nil {
 		return nil, err
@@ -574,6 +813,13 @@ func (c *Client) GetChecks() (Checks, error) {
 //
 // https://updown.io/api#GET-/api/checks/:token
 func (c *Client) GetCheck(token string, withMetrics bool) (*Check, error) {
+	return c.GetCheckContext(context.Background(), token, withMetrics)
+}
+
+// GetCheckContext returns info about check with given token using given context
+//
+// https://updown.io/api#GET-/api/checks/:token
+func (c *Client) GetCheckContext(ctx context.Context, token string, withMetrics bool) (*Check, error) {
 	switch {
 	case c == nil || c.engine == nil:
 		return nil, ErrNilClient
@@ -588,7 +834,35 @@ func (c *Client) GetCheck(token string, withMetrics bool) (*Check, error) {
 	}
 
 	result := &Check{}
-	err := c.sendRequest(req.GET, "/checks/"+token, &result, nil, query)
+	err := c.sendRequest(ctx, req.GET, "/checks/"+token, &result, nil, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateCheck creates new check
+//
+// https://updown.io/api#POST-/api/checks
+func (c *Client) CreateCheck(options CheckOptions) (*Check, error) {
+	return c.CreateCheckContext(context.Background(), options)
+}
+
+// CreateCheckContext creates new check using given context
+//
+// https://updown.io/api#POST-/api/checks
+func (c *Client) CreateCheckContext(ctx context.Context, options CheckOptions) (*Check, error) {
+	switch {
+	case c == nil || c.engine == nil:
+		return nil, ErrNilClient
+	case options.URL == "":
+		return nil, ErrEmptyURL
+	}
+
+	result := &Check{}
+	err := c.sendRequest(ctx, req.POST, "/checks", &result, options, nil)
 
 	if err != nil {
 		return nil, err
@@ -597,10 +871,142 @@ func (c *Client) GetCheck(token string, withMetrics bool) (*Check, error) {
 	return result, nil
 }
 
+// UpdateCheck updates check with given token
+//
+// https://updown.io/api#PUT-/api/checks/:token
+func (c *Client) UpdateCheck(token string, options CheckOptions) (*Check, error) {
+	return c.UpdateCheckContext(context.Background(), token, options)
+}
+
+// UpdateCheckContext updates check with given token using given context
+//
+// https://updown.io/api#PUT-/api/checks/:token
+func (c *Client) UpdateCheckContext(ctx context.Context, token string, options CheckOptions) (*Check, error) {
+	switch {
+	case c == nil || c.engine == nil:
+		return nil, ErrNilClient
+	case token == "":
+		return nil, ErrEmptyToken
+	}
+
+	result := &Check{}
+	err := c.sendRequest(ctx, req.PUT, "/checks/"+token, &result, options, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteCheck deletes check with given token
+//
+// https://updown.io/api#DELETE-/api/checks/:token
+func (c *Client) DeleteCheck(token string) error {
+	return c.DeleteCheckContext(context.Background(), token)
+}
+
+// DeleteCheckContext deletes check with given token using given context
+//
+// https://updown.io/api#DELETE-/api/checks/:token
+func (c *Client) DeleteCheckContext(ctx context.Context, token string) error {
+	switch {
+	case c == nil || c.engine == nil:
+		return ErrNilClient
+	case token == "":
+		return ErrEmptyToken
+	}
+
+	result := &deleteResponse{}
+	err := c.sendRequest(ctx, req.DELETE, "/checks/"+token, &result, nil, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if !result.IsDeleted {
+		return ErrNotDeleted
+	}
+
+	return nil
+}
+
+// MuteCheck mutes notifications for check with given token until given date
+//
+// https://updown.io/api#PUT-/api/checks/:token
+func (c *Client) MuteCheck(token string, until time.Time) (*Check, error) {
+	return c.MuteCheckContext(context.Background(), token, until)
+}
+
+// MuteCheckContext mutes notifications for check with given token until given date using given context
+//
+// https://updown.io/api#PUT-/api/checks/:token
+func (c *Client) MuteCheckContext(ctx context.Context, token string, until time.Time) (*Check, error) {
+	if until.IsZero() {
+		return nil, ErrEmptyMuteDate
+	}
+
+	mute := until.UTC().Format(time.RFC3339)
+
+	return c.UpdateCheckContext(ctx, token, CheckOptions{MuteUntil: &mute})
+}
+
+// UnmuteCheck unmutes notifications for check with given token
+//
+// https://updown.io/api#PUT-/api/checks/:token
+func (c *Client) UnmuteCheck(token string) (*Check, error) {
+	return c.UnmuteCheckContext(context.Background(), token)
+}
+
+// UnmuteCheckContext unmutes notifications for check with given token using given context
+//
+// https://updown.io/api#PUT-/api/checks/:token
+func (c *Client) UnmuteCheckContext(ctx context.Context, token string) (*Check, error) {
+	mute := ""
+	return c.UpdateCheckContext(ctx, token, CheckOptions{MuteUntil: &mute})
+}
+
+// EnableCheck enables check with given token
+//
+// https://updown.io/api#PUT-/api/checks/:token
+func (c *Client) EnableCheck(token string) (*Check, error) {
+	return c.EnableCheckContext(context.Background(), token)
+}
+
+// EnableCheckContext enables check with given token using given context
+//
+// https://updown.io/api#PUT-/api/checks/:token
+func (c *Client) EnableCheckContext(ctx context.Context, token string) (*Check, error) {
+	enabled := true
+	return c.UpdateCheckContext(ctx, token, CheckOptions{IsEnabled: &enabled})
+}
+
+// DisableCheck disables check with given token
+//
+// https://updown.io/api#PUT-/api/checks/:token
+func (c *Client) DisableCheck(token string) (*Check, error) {
+	return c.DisableCheckContext(context.Background(), token)
+}
+
+// DisableCheckContext disables check with given token using given context
+//
+// https://updown.io/api#PUT-/api/checks/:token
+func (c *Client) DisableCheckContext(ctx context.Context, token string) (*Check, error) {
+	enabled := false
+	return c.UpdateCheckContext(ctx, token, CheckOptions{IsEnabled: &enabled})
+}
+
 // GetDowntimes returns all the downtimes of a check
 //
 // https://updown.io/api#GET-/api/checks/:token/downtimes
 func (c *Client) GetDowntimes(token string, detailed bool) (Downtimes, error) {
+	return c.GetDowntimesContext(context.Background(), token, detailed)
+}
+
+// GetDowntimesContext returns all the downtimes of a check using given context
+//
+// https://updown.io/api#GET-/api/checks/:token/downtimes
+func (c *Client) GetDowntimesContext(ctx context.Context, token string, detailed bool) (Downtimes, error) {
 	switch {
 	case c == nil || c.engine == nil:
 		return nil, ErrNilClient
@@ -620,7 +1026,7 @@ func (c *Client) GetDowntimes(token string, detailed bool) (Downtimes, error) {
 		query["page"] = page
 		downtimes := Downtimes{}
 		err := c.sendRequest(
-			req.GET, "/checks/"+token+"/downtimes",
+			ctx, req.GET, "/checks/"+token+"/downtimes",
 			&downtimes, nil, query,
 		)
 
@@ -638,10 +1044,110 @@ func (c *Client) GetDowntimes(token string, detailed bool) (Downtimes, error) {
 	return result, nil
 }
 
-// GetMetrics returns detailed metrics about the check
+// GetMetrics returns detailed metrics about the check. Grouping option is ignored,
+// use GetMetricsByTime or GetMetricsByHost for grouped metrics.
 //
 // https://updown.io/api#GET-/api/checks/:token/metrics
 func (c *Client) GetMetrics(token string, options MetricsOptions) (*Metrics, error) {
+	return c.GetMetricsContext(context.Background(), token, options)
+}
+
+// GetMetricsContext returns detailed metrics about the check using given context.
+// Grouping option is ignored, use GetMetricsByTimeContext or
+// GetMetricsByHostContext for grouped metrics.
+//
+// https://updown.io/api#GET-/api/checks/:token/metrics
+func (c *Client) GetMetricsContext(ctx context.Context, token string, options MetricsOptions) (*Metrics, error) {
+	switch {
+	case c == nil || c.engine == nil:
+		return nil, ErrNilClient
+	case token == "":
+		return nil, ErrEmptyToken
+	}
+
+	options.GroupBy = ""
+
+	err := options.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Metrics
+
+	for _, o := range options.split(c.getMetricsMaxRange()) {
+		metrics := &Metrics{}
+		err = c.sendRequest(ctx, req.GET, "/checks/"+token+"/metrics", &metrics, nil, o.toQuery())
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, metrics)
+	}
+
+	return mergeMetrics(result), nil
+}
+
+// GetMetricsByTime returns detailed metrics about the check grouped by hour
+//
+// https://updown.io/api#GET-/api/checks/:token/metrics
+func (c *Client) GetMetricsByTime(token string, options MetricsOptions) (MetricsSeries, error) {
+	return c.GetMetricsByTimeContext(context.Background(), token, options)
+}
+
+// GetMetricsByTimeContext returns detailed metrics about the check grouped by hour using given context
+//
+// https://updown.io/api#GET-/api/checks/:token/metrics
+func (c *Client) GetMetricsByTimeContext(ctx context.Context, token string, options MetricsOptions) (MetricsSeries, error) {
+	switch {
+	case c == nil || c.engine == nil:
+		return nil, ErrNilClient
+	case token == "":
+		return nil, ErrEmptyToken
+	}
+
+	options.GroupBy = GROUP_BY_TIME
+
+	err := options.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result MetricsSeries
+
+	for _, o := range options.split(c.getMetricsMaxRange()) {
+		series := MetricsSeries{}
+		err = c.sendRequest(ctx, req.GET, "/checks/"+token+"/metrics", &series, nil, o.toQuery())
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = result.append(series)
+	}
+
+	if result == nil {
+		result = MetricsSeries{}
+	}
+
+	return result, nil
+}
+
+// GetMetricsByHost returns detailed metrics about the check grouped by monitoring
+// node
+//
+// https://updown.io/api#GET-/api/checks/:token/metrics
+func (c *Client) GetMetricsByHost(token string, options MetricsOptions) (HostsMetrics, error) {
+	return c.GetMetricsByHostContext(context.Background(), token, options)
+}
+
+// GetMetricsByHostContext returns detailed metrics about the check grouped by
+// monitoring node using given context
+//
+// https://updown.io/api#GET-/api/checks/:token/metrics
+func (c *Client) GetMetricsByHostContext(ctx context.Context, token string, options MetricsOptions) (HostsMetrics, error) {
 	switch {
 	case c == nil || c.engine == nil:
 		return nil, ErrNilClient
@@ -649,15 +1155,35 @@ func (c *Client) GetMetrics(token string, options MetricsOptions) (*Metrics, err
 		return nil, ErrEmptyToken
 	}
 
-	query := options.toQuery()
-	result := &Metrics{}
+	options.GroupBy = GROUP_BY_HOST
 
-	err := c.sendRequest(req.GET, "/checks/"+token+"/metrics", &result, nil, query)
+	err := options.Validate()
 
 	if err != nil {
 		return nil, err
 	}
 
+	hosts := map[string][]*Metrics{}
+
+	for _, o := range options.split(c.getMetricsMaxRange()) {
+		metrics := HostsMetrics{}
+		err = c.sendRequest(ctx, req.GET, "/checks/"+token+"/metrics", &metrics, nil, o.toQuery())
+
+		if err != nil {
+			return nil, err
+		}
+
+		for host, m := range metrics {
+			hosts[host] = append(hosts[host], m)
+		}
+	}
+
+	result := HostsMetrics{}
+
+	for host, m := range hosts {
+		result[host] = mergeMetrics(m)
+	}
+
 	return result, nil
 }
 
@@ -665,12 +1191,19 @@ func (c *Client) GetMetrics(token string, options MetricsOptions) (*Metrics, err
 //
 // https://updown.io/api#GET-/api/nodes
 func (c *Client) GetNodes() (Nodes, error) {
+	return c.GetNodesContext(context.Background())
+}
+
+// GetNodesContext return list of all updown.io servers (monitoring & webhooks) using given context
+//
+// https://updown.io/api#GET-/api/nodes
+func (c *Client) GetNodesContext(ctx context.Context) (Nodes, error) {
 	if c == nil || c.engine == nil {
 		return nil, ErrNilClient
 	}
 
 	result := Nodes{}
-	err := c.sendRequest(req.GET, "/nodes", &result, nil, nil)
+	err := c.sendRequest(ctx, req.GET, "/nodes", &result, nil, nil)
 
 	if err != nil {
 		return nil, err
@@ -683,12 +1216,19 @@ func (c *Client) GetNodes() (Nodes, error) {
 //
 // https://updown.io/api#GET-/api/nodes/ips
 func (c *Client) GetNodesIPs() ([]string, error) {
+	return c.GetNodesIPsContext(context.Background())
+}
+
+// GetNodesIPsContext returns list all updown.io servers addresses using given context
+//
+// https://updown.io/api#GET-/api/nodes/ips
+func (c *Client) GetNodesIPsContext(ctx context.Context) ([]string, error) {
 	if c == nil || c.engine == nil {
 		return nil, ErrNilClient
 	}
 
 	result := []string{}
-	err := c.sendRequest(req.GET, "/nodes/ips", &result, nil, nil)
+	err := c.sendRequest(ctx, req.GET, "/nodes/ips", &result, nil, nil)
 
 	if err != nil {
 		return nil, err
@@ -701,12 +1241,19 @@ func (c *Client) GetNodesIPs() ([]string, error) {
 //
 // https://updown.io/api#GET-/api/nodes/ipv4
 func (c *Client) GetNodesIPsV4() ([]string, error) {
+	return c.GetNodesIPsV4Context(context.Background())
+}
+
+// GetNodesIPsV4Context returns list all updown.io servers IPv4 addresses using given context
+//
+// https://updown.io/api#GET-/api/nodes/ipv4
+func (c *Client) GetNodesIPsV4Context(ctx context.Context) ([]string, error) {
 	if c == nil || c.engine == nil {
 		return nil, ErrNilClient
 	}
 
 	result := []string{}
-	err := c.sendRequest(req.GET, "/nodes/ipv4", &result, nil, nil)
+	err := c.sendRequest(ctx, req.GET, "/nodes/ipv4", &result, nil, nil)
 
 	if err != nil {
 		return nil, err
@@ -719,12 +1266,19 @@ func (c *Client) GetNodesIPsV4() ([]string, error) {
 //
 // https://updown.io/api#GET-/api/nodes/ipv6
 func (c *Client) GetNodesIPsV6() ([]string, error) {
+	return c.GetNodesIPsV6Context(context.Background())
+}
+
+// GetNodesIPsV6Context returns list all updown.io servers IPv6 addresses using given context
+//
+// https://updown.io/api#GET-/api/nodes/ipv6
+func (c *Client) GetNodesIPsV6Context(ctx context.Context) ([]string, error) {
 	if c == nil || c.engine == nil {
 		return nil, ErrNilClient
 	}
 
 	result := []string{}
-	err := c.sendRequest(req.GET, "/nodes/ipv6", &result, nil, nil)
+	err := c.sendRequest(ctx, req.GET, "/nodes/ipv6", &result, nil, nil)
 
 	if err != nil {
 		return nil, err
@@ -737,12 +1291,19 @@ func (c *Client) GetNodesIPsV6() ([]string, error) {
 //
 // https://updown.io/api#GET-/api/recipients
 func (c *Client) GetRecipients() (Recipients, error) {
+	return c.GetRecipientsContext(context.Background())
+}
+
+// GetRecipientsContext returns list all the possible alert recipients/channels on your account using given context
+//
+// https://updown.io/api#GET-/api/recipients
+func (c *Client) GetRecipientsContext(ctx context.Context) (Recipients, error) {
 	if c == nil || c.engine == nil {
 		return nil, ErrNilClient
 	}
 
 	result := Recipients{}
-	err := c.sendRequest(req.GET, "/recipients", &result, nil, nil)
+	err := c.sendRequest(ctx, req.GET, "/recipients", &result, nil, nil)
 
 	if err != nil {
 		return nil, err
@@ -755,12 +1316,19 @@ func (c *Client) GetRecipients() (Recipients, error) {
 //
 // https://updown.io/api#GET-/api/status-pages
 func (c *Client) GetStatusPages() (StatusPages, error) {
+	return c.GetStatusPagesContext(context.Background())
+}
+
+// GetStatusPagesContext returns list all your status pages using given context
+//
+// https://updown.io/api#GET-/api/status-pages
+func (c *Client) GetStatusPagesContext(ctx context.Context) (StatusPages, error) {
 	if c == nil || c.engine == nil {
 		return nil, ErrNilClient
 	}
 
 	result := StatusPages{}
-	err := c.sendRequest(req.GET, "/status-pages", &result, nil, nil)
+	err := c.sendRequest(ctx, req.GET, "/status-pages", &result, nil, nil)
 
 	if err != nil {
 		return nil, err
@@ -791,6 +1359,15 @@ func (d *Date) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON encodes date to JSON using the same format as API
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return []byte(d.UTC().Format(`"2006-01-02T15:04:05Z"`)), nil
+}
+
 // UnmarshalJSON parses performance metrics
 func (d *PerformanceMetrics) UnmarshalJSON(b []byte) error {
 	if len(b) == 0 || string(b) == "null" {
@@ -821,8 +1398,56 @@ func (d *PerformanceMetrics) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// UnmarshalJSON parses metrics grouped by time
+func (s *MetricsSeries) UnmarshalJSON(b []byte) error {
+	if len(b) == 0 || string(b) == "null" {
+		return nil
+	}
+
+	metrics := map[string]*Metrics{}
+	err := json.Unmarshal(b, &metrics)
+
+	if err != nil {
+		return err
+	}
+
+	series := make(MetricsSeries, 0, len(metrics))
+
+	for k, v := range metrics {
+		dt, err := time.Parse(time.RFC3339, k)
+
+		if err != nil {
+			return err
+		}
+
+		series = append(series, &MetricsPoint{Time: dt, Metrics: v})
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Time.Before(series[j].Time)
+	})
+
+	*s = series
+
+	return nil
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// Validate validates metrics options
+func (o MetricsOptions) Validate() error {
+	switch {
+	case !o.From.IsZero() && !o.To.IsZero() && o.To.Before(o.From):
+		return ErrInvalidMetricsRange
+	case o.From.After(time.Now()):
+		return ErrFutureMetricsRange
+	case o.GroupBy != "" && o.GroupBy != GROUP_BY_TIME && o.GroupBy != GROUP_BY_HOST:
+		return ErrInvalidMetricsGroup
+	}
+
+	return nil
+}
+
 // toQuery converts options into request query
 func (o MetricsOptions) toQuery() req.Query {
 	if o.From.IsZero() && o.To.IsZero() && o.GroupBy == "" {
@@ -831,45 +1456,385 @@ func (o MetricsOptions) toQuery() req.Query {
 
 	query := req.Query{}
 
-	query.SetIf(o.GroupBy != "", "group", o.GroupBy)
+	query.SetIf(o.GroupBy != "", "group", string(o.GroupBy))
 	query.SetIf(!o.From.IsZero(), "from", o.From.Format("2006-01-02T15:04:05-07:00")) // ISO8601
-	query.SetIf(!o.From.IsZero(), "to", o.To.Format("2006-01-02T15:04:05-07:00"))     // ISO8601
+	query.SetIf(!o.To.IsZero(), "to", o.To.Format("2006-01-02T15:04:05-07:00"))       // ISO8601
 
 	return query
 }
 
+// split splits options with long range into multiple options with range not
+// longer than given maximum range
+func (o MetricsOptions) split(maxRange time.Duration) []MetricsOptions {
+	if o.From.IsZero() {
+		return []MetricsOptions{o}
+	}
+
+	to := o.To
+
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	if to.Sub(o.From) <= maxRange {
+		return []MetricsOptions{o}
+	}
+
+	var result []MetricsOptions
+
+	for from := o.From; from.Before(to); from = from.Add(maxRange) {
+		result = append(result, MetricsOptions{
+			From:    from,
+			To:      minTime(from.Add(maxRange), to),
+			GroupBy: o.GroupBy,
+		})
+	}
+
+	return result
+}
+
+// append appends points from given series merging points for the same hour
+func (s MetricsSeries) append(series MetricsSeries) MetricsSeries {
+	index := make(map[int64]*MetricsPoint, len(s))
+
+	for _, p := range s {
+		index[p.Time.Unix()] = p
+	}
+
+	for _, p := range series {
+		point := index[p.Time.Unix()]
+
+		if point == nil {
+			index[p.Time.Unix()] = p
+			s = append(s, p)
+			continue
+		}
+
+		point.Metrics = mergeMetrics([]*Metrics{point.Metrics, p.Metrics})
+	}
+
+	sort.Slice(s, func(i, j int) bool {
+		return s[i].Time.Before(s[j].Time)
+	})
+
+	return s
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// sendRequest sends request to API
-func (c *Client) sendRequest(method, endpoint string, response, _ any, query req.Query) error {
-	c.calls++
+// parseMetricsRange parses relative metrics range
+func parseMetricsRange(rng string, now time.Time) (MetricsOptions, error) {
+	rng = strings.ToLower(strings.TrimSpace(rng))
+
+	switch rng {
+	case "today":
+		return MetricsOptions{From: timeutil.StartOfDay(now), To: now}, nil
+	case "yesterday":
+		return MetricsOptions{
+			From: timeutil.StartOfDay(timeutil.PrevDay(now)),
+			To:   timeutil.StartOfDay(now),
+		}, nil
+	case "this week":
+		return MetricsOptions{From: timeutil.StartOfWeek(now, time.Monday), To: now}, nil
+	case "last week":
+		return MetricsOptions{
+			From: timeutil.StartOfWeek(timeutil.PrevWeek(now, time.Monday), time.Monday),
+			To:   timeutil.StartOfWeek(now, time.Monday),
+		}, nil
+	case "this month":
+		return MetricsOptions{From: timeutil.StartOfMonth(now), To: now}, nil
+	case "last month":
+		return MetricsOptions{
+			From: timeutil.StartOfMonth(timeutil.PrevMonth(timeutil.StartOfMonth(now))),
+			To:   timeutil.StartOfMonth(now),
+		}, nil
+	}
 
-	r := req.Request{
-		Method:  method,
-		URL:     apiURL + endpoint,
-		Query:   query,
-		Accept:  req.CONTENT_TYPE_JSON,
-		Headers: defaultHeaders,
-		Auth:    req.AuthAPIKey{c.apiKey},
+	dur, ok := strings.CutPrefix(rng, "last ")
+
+	if !ok {
+		return MetricsOptions{}, fmt.Errorf("Unsupported metrics range %q", rng)
 	}
 
-	resp, err := c.engine.Do(r)
+	d, err := timeutil.ParseDuration(strings.TrimSpace(dur), 'h')
 
 	if err != nil {
-		return fmt.Errorf("Can't send request to API: %w", err)
+		return MetricsOptions{}, fmt.Errorf("Can't parse metrics range %q: %w", rng, err)
 	}
 
-	if resp.StatusCode != req.STATUS_OK {
-		return fmt.Errorf("API returned non-ok status code %d", resp.StatusCode)
+	if d <= 0 {
+		return MetricsOptions{}, fmt.Errorf("Invalid metrics range %q", rng)
+	}
+
+	return MetricsOptions{From: now.Add(-d), To: now}, nil
+}
+
+// minTime returns the earliest of two dates
+func minTime(t1, t2 time.Time) time.Time {
+	if t1.Before(t2) {
+		return t1
+	}
+
+	return t2
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sendRequest sends request to API. Context is used as a parent context for
+// instrumentation and request isn't sent if context is already cancelled.
+func (c *Client) sendRequest(ctx context.Context, method, endpoint string, response, body any, query req.Query) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := ctx.Err()
+
+	if err != nil {
+		return err
+	}
+
+	if c.instrumentation == nil {
+		_, err = c.doRequest(method, endpoint, response, body, query, nil)
+		return err
+	}
+
+	info := &RequestInfo{
+		Method:   method,
+		Endpoint: endpoint,
+		Route:    getRoute(endpoint),
+		Token:    getToken(endpoint),
+		Start:    time.Now(),
+	}
+
+	headers := req.Headers{}
+
+	startRequest(ctx, c.instrumentation, info, headers)
+
+	info.StatusCode, info.Error = c.doRequest(method, endpoint, response, body, query, headers)
+	info.Duration = time.Since(info.Start)
+
+	c.instrumentation.RequestDone(info)
+
+	return info.Error
+}
+
+// doRequest sends request through middlewares chain and decodes response
+func (c *Client) doRequest(method, endpoint string, response, body any, query req.Query, headers req.Headers) (int, error) {
+	r := &APIRequest{
+		Method:   method,
+		Endpoint: endpoint,
+		Query:    query,
+		Headers:  req.Headers{},
+		Body:     body,
+	}
+
+	for k, v := range defaultHeaders {
+		r.Headers[k] = v
+	}
+
+	for k, v := range headers {
+		r.Headers[k] = v
+	}
+
+	rt := c.roundTrip
+
+	if cache := c.cache.Load(); cache != nil {
+		rt = cache.wrap(rt)
+	}
+
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		rt = c.middlewares[i](rt)
+	}
+
+	resp, err := rt(r)
+
+	switch {
+	case err != nil:
+		return 0, err
+	case resp == nil:
+		return 0, ErrNilResponse
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, &statusError{
+			code:       resp.StatusCode,
+			retryAfter: getRetryAfter(resp.Header, time.Now()),
+		}
 	}
 
 	if response != nil {
-		err = resp.JSON(response)
+		err = json.Unmarshal(resp.Body, response)
 
 		if err != nil {
-			return fmt.Errorf("Can't decode API response: %w", err)
+			return resp.StatusCode, fmt.Errorf("Can't decode API response: %w", err)
 		}
 	}
 
-	return nil
+	return resp.StatusCode, nil
+}
+
+// roundTrip sends request to API
+func (c *Client) roundTrip(r *APIRequest) (*APIResponse, error) {
+	c.limiter.Load().Wait()
+	c.calls.Add(1)
+
+	resp, err := c.engine.Do(req.Request{
+		Method:  r.Method,
+		URL:     c.getURL() + r.Endpoint,
+		Query:   r.Query,
+		Body:    r.Body,
+		Accept:  req.CONTENT_TYPE_JSON,
+		Headers: r.Headers,
+		Auth:    req.AuthAPIKey{Key: c.apiKey},
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't send request to API: %w", err)
+	}
+
+	data, err := resp.Bytes()
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read API response: %w", err)
+	}
+
+	return &APIResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       data,
+	}, nil
+}
+
+// Error returns error message
+func (e *statusError) Error() string {
+	return fmt.Sprintf("API returned non-ok status code %d", e.code)
+}
+
+// getURL returns API URL
+func (c *Client) getURL() string {
+	if c.url != "" {
+		return c.url
+	}
+
+	return apiURL
+}
+
+// getMetricsMaxRange returns maximum range of single metrics request
+func (c *Client) getMetricsMaxRange() time.Duration {
+	if d := time.Duration(c.metricsRange.Load()); d > 0 {
+		return d
+	}
+
+	return METRICS_MAX_RANGE
+}
+
+// startRequest notifies instrumentation about request start if it supports
+// tracing and stores request context in info
+func startRequest(ctx context.Context, i Instrumentation, info *RequestInfo, headers req.Headers) {
+	info.Context = ctx
+
+	tracer, ok := i.(RequestTracer)
+
+	if !ok {
+		return
+	}
+
+	if rctx := tracer.RequestStart(ctx, info, headers); rctx != nil {
+		info.Context = rctx
+	}
+}
+
+// doWithRetry sends request with retries and returns response and number
+// of attempts. Retries are stopped if context is cancelled.
+func doWithRetry(ctx context.Context, engine *req.Engine, r req.Request, retry req.Retry) (*req.Response, int, error) {
+	var err error
+	var resp *req.Response
+
+	for attempt := 1; attempt <= retry.Num; attempt++ {
+		if ctx.Err() != nil {
+			return nil, attempt - 1, ctx.Err()
+		}
+
+		resp, err = engine.Do(r)
+
+		if err == nil {
+			if retry.Status == 0 || resp.StatusCode == retry.Status {
+				return resp, attempt, nil
+			}
+
+			resp.Discard()
+			err = fmt.Errorf("Server returned non-ok status code %d", resp.StatusCode)
+		}
+
+		if attempt < retry.Num {
+			timer := time.NewTimer(retry.Pause)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return resp, attempt, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	return resp, retry.Num, err
+}
+
+// getRetryAfter returns delay from Retry-After header (delay in seconds or
+// HTTP date). Returns -1 if header is not set or malformed.
+func getRetryAfter(h http.Header, now time.Time) time.Duration {
+	v := h.Get("Retry-After")
+
+	if v == "" {
+		return -1
+	}
+
+	if sec, err := strconv.Atoi(v); err == nil && sec >= 0 {
+		return time.Duration(sec) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0)
+	}
+
+	return -1
+}
+
+// getRoute returns API endpoint with token replaced by placeholder
+func getRoute(endpoint string) string {
+	token := getToken(endpoint)
+
+	if token == "" {
+		return endpoint
+	}
+
+	return strings.Replace(endpoint, "/"+token, "/:token", 1)
+}
+
+// getToken extracts check token from API endpoint
+func getToken(endpoint string) string {
+	rest, ok := strings.CutPrefix(endpoint, "/checks/")
+
+	if !ok {
+		return ""
+	}
+
+	token, _, _ := strings.Cut(rest, "/")
+
+	return token
+}
+
+// parsePulseURL extracts host and check token from pulse URL
+func parsePulseURL(url string) (string, string) {
+	scheme, rest, ok := strings.Cut(url, "://")
+
+	if !ok {
+		return "", ""
+	}
+
+	host, path, _ := strings.Cut(rest, "/")
+	token, _, _ := strings.Cut(path, "/")
+
+	return scheme + "://" + host, token
 }
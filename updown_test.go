@@ -8,10 +8,16 @@ package updown
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/essentialkaos/ek/v13/req"
+
 	. "github.com/essentialkaos/check"
 )
 
@@ -39,6 +45,10 @@ func (s *UpdownSuite) SetUpSuite(c *C) {
 
 	mux.HandleFunc("GET /checks", handlerChecks)
 	mux.HandleFunc("GET /checks/ngg8", handlerCheck)
+	mux.HandleFunc("POST /checks", handlerCheckWrite)
+	mux.HandleFunc("PUT /checks/ngg8", handlerCheckWrite)
+	mux.HandleFunc("DELETE /checks/ngg8", handlerCheckDelete)
+	mux.HandleFunc("DELETE /checks/xyz1", handlerCheckDelete)
 	mux.HandleFunc("GET /checks/ngg8/downtimes", handlerDowntimes)
 	mux.HandleFunc("GET /checks/ngg8/metrics", handlerMetrics)
 	mux.HandleFunc("GET /nodes", handlerNodes)
@@ -49,6 +59,8 @@ func (s *UpdownSuite) SetUpSuite(c *C) {
 	mux.HandleFunc("GET /status-pages", handlerStatusPages)
 
 	mux.HandleFunc("POST /pulse", handlerPulse)
+	mux.HandleFunc("GET /pulse", handlerPulse)
+	mux.HandleFunc("GET /pulse-invalid", handlerPulseInvalid)
 	mux.HandleFunc("POST /pulse-error", handlerPulseError)
 
 	go server.ListenAndServe()
@@ -404,6 +416,8 @@ func (s *UpdownSuite) TestBasicErrors(c *C) {
 	var api *Client
 
 	api.SetUserAgent("test", "1")
+	api.SetURL("http://127.0.0.1")
+	api.SetHTTPClient(nil)
 
 	c.Assert(api.Calls(), Equals, uint(0))
 
@@ -419,6 +433,12 @@ func (s *UpdownSuite) TestBasicErrors(c *C) {
 	_, err = api.GetMetrics("ngg8", MetricsOptions{})
 	c.Assert(err, NotNil)
 
+	_, err = api.GetMetricsByTime("ngg8", MetricsOptions{})
+	c.Assert(err, NotNil)
+
+	_, err = api.GetMetricsByHost("ngg8", MetricsOptions{})
+	c.Assert(err, NotNil)
+
 	_, err = api.GetNodes()
 	c.Assert(err, NotNil)
 
@@ -437,6 +457,15 @@ func (s *UpdownSuite) TestBasicErrors(c *C) {
 	_, err = api.GetStatusPages()
 	c.Assert(err, NotNil)
 
+	_, err = api.CreateCheck(CheckOptions{URL: "https://domain.com"})
+	c.Assert(err, NotNil)
+
+	_, err = api.UpdateCheck("ngg8", CheckOptions{})
+	c.Assert(err, NotNil)
+
+	err = api.DeleteCheck("ngg8")
+	c.Assert(err, NotNil)
+
 	api, err = NewClient("test1234")
 
 	c.Assert(err, IsNil)
@@ -445,16 +474,222 @@ func (s *UpdownSuite) TestBasicErrors(c *C) {
 	_, err = api.GetCheck("", false)
 	c.Assert(err, NotNil)
 
+	_, err = api.CreateCheck(CheckOptions{})
+	c.Assert(err, Equals, ErrEmptyURL)
+
+	_, err = api.UpdateCheck("", CheckOptions{})
+	c.Assert(err, Equals, ErrEmptyToken)
+
+	err = api.DeleteCheck("")
+	c.Assert(err, Equals, ErrEmptyToken)
+
 	_, err = api.GetDowntimes("", false)
 	c.Assert(err, NotNil)
 
 	_, err = api.GetMetrics("", MetricsOptions{})
 	c.Assert(err, NotNil)
 
+	_, err = api.GetMetricsByTime("", MetricsOptions{})
+	c.Assert(err, NotNil)
+
+	_, err = api.GetMetricsByHost("", MetricsOptions{})
+	c.Assert(err, NotNil)
+
 	var cc *Check
 	c.Assert(cc.Link(), Equals, "https://updown.io")
 }
 
+func (s *UpdownSuite) TestInstrumentation(c *C) {
+	var nc *Client
+
+	nc.SetInstrumentation(&testInstrumentation{})
+
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+
+	inst := &testInstrumentation{}
+	api.SetInstrumentation(inst)
+
+	_, err = api.GetCheck("ngg8", false)
+	c.Assert(err, IsNil)
+
+	_, err = api.GetCheck("abcd", false)
+	c.Assert(err, NotNil)
+
+	c.Assert(inst.requests, HasLen, 2)
+	c.Assert(inst.requests[0].Method, Equals, "GET")
+	c.Assert(inst.requests[0].Endpoint, Equals, "/checks/ngg8")
+	c.Assert(inst.requests[0].Route, Equals, "/checks/:token")
+	c.Assert(inst.requests[0].Token, Equals, "ngg8")
+	c.Assert(inst.requests[0].StatusCode, Equals, 200)
+	c.Assert(inst.requests[0].Attempts, Equals, 0)
+	c.Assert(inst.requests[0].Start.IsZero(), Equals, false)
+	c.Assert(inst.requests[0].Error, IsNil)
+	c.Assert(inst.requests[1].StatusCode, Equals, 404)
+	c.Assert(inst.requests[1].Error, NotNil)
+
+	SetPulseInstrumentation(inst)
+	defer SetPulseInstrumentation(nil)
+
+	_, err = SendPulse("http://127.0.0.1:"+TEST_PORT+"/pulse", "TEST-DATA")
+	c.Assert(err, IsNil)
+
+	c.Assert(inst.requests, HasLen, 3)
+	c.Assert(inst.requests[2].Method, Equals, "POST")
+	c.Assert(inst.requests[2].Endpoint, Equals, "http://127.0.0.1:"+TEST_PORT)
+	c.Assert(inst.requests[2].Route, Equals, "/:token/:key")
+	c.Assert(inst.requests[2].Token, Equals, "pulse")
+	c.Assert(inst.requests[2].Attempts, Equals, 1)
+
+	c.Assert(getRoute("/checks"), Equals, "/checks")
+	c.Assert(getRoute("/checks/ngg8/downtimes"), Equals, "/checks/:token/downtimes")
+	c.Assert(getToken("/nodes/ipv4"), Equals, "")
+
+	host, token := parsePulseURL("https://pulse.updown.io/ngg8/AbCd")
+	c.Assert(host, Equals, "https://pulse.updown.io")
+	c.Assert(token, Equals, "ngg8")
+
+	host, token = parsePulseURL("pulse.updown.io/ngg8/AbCd")
+	c.Assert(host, Equals, "")
+	c.Assert(token, Equals, "")
+}
+
+func (s *UpdownSuite) TestRequestTracer(c *C) {
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+
+	var headers []string
+
+	inst := &testTracer{}
+	api.SetInstrumentation(inst)
+	api.Use(func(next RoundTrip) RoundTrip {
+		return func(r *APIRequest) (*APIResponse, error) {
+			headers = append(headers, r.Headers["traceparent"])
+			return next(r)
+		}
+	})
+
+	_, err = api.GetCheck("ngg8", false)
+	c.Assert(err, IsNil)
+
+	c.Assert(headers, DeepEquals, []string{"/checks/ngg8"})
+	c.Assert(inst.requests, HasLen, 1)
+	c.Assert(inst.requests[0].Context.Value(testCtxKey("started")), Equals, true)
+
+	ctx := context.WithValue(context.Background(), testCtxKey("parent"), "api")
+	_, err = api.GetCheckContext(ctx, "ngg8", false)
+	c.Assert(err, IsNil)
+
+	_, errs := api.GetChecksDetailedContext(ctx, []string{"ngg8"})
+	c.Assert(errs, HasLen, 0)
+
+	c.Assert(inst.requests, HasLen, 3)
+	c.Assert(inst.requests[1].Context.Value(testCtxKey("parent")), Equals, "api")
+	c.Assert(inst.requests[2].Context.Value(testCtxKey("parent")), Equals, "api")
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	calls := api.Calls()
+	_, err = api.GetChecksContext(cctx)
+	c.Assert(err, Equals, context.Canceled)
+	c.Assert(api.Calls(), Equals, calls)
+	c.Assert(inst.requests, HasLen, 3)
+
+	pc := NewPulseClient()
+	pc.Instrumentation = inst
+
+	ctx = context.WithValue(context.Background(), testCtxKey("parent"), "pulse")
+	_, err = pc.SendContext(ctx, "http://127.0.0.1:"+TEST_PORT+"/pulse", "")
+	c.Assert(err, IsNil)
+
+	c.Assert(inst.requests, HasLen, 4)
+	c.Assert(inst.requests[3].Context.Value(testCtxKey("started")), Equals, true)
+	c.Assert(inst.requests[3].Context.Value(testCtxKey("parent")), Equals, "pulse")
+	c.Assert(inst.requests[3].Attempts, Equals, 1)
+}
+
+func (s *UpdownSuite) TestMiddlewares(c *C) {
+	var nc *Client
+	var log []string
+
+	nc.Use(nil)
+
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+
+	api.Use(nil)
+	api.Use(func(next RoundTrip) RoundTrip {
+		return func(r *APIRequest) (*APIResponse, error) {
+			log = append(log, "1:"+r.Method+" "+r.Endpoint)
+			resp, err := next(r)
+
+			if resp != nil {
+				log = append(log, fmt.Sprintf("1:%d", resp.StatusCode))
+			}
+
+			return resp, err
+		}
+	})
+	api.Use(func(next RoundTrip) RoundTrip {
+		return func(r *APIRequest) (*APIResponse, error) {
+			log = append(log, fmt.Sprintf("2:%v", r.Query["metrics"]))
+			return next(r)
+		}
+	})
+
+	_, err = api.GetCheck("ngg8", true)
+
+	c.Assert(err, IsNil)
+	c.Assert(log, DeepEquals, []string{"1:GET /checks/ngg8", "2:true", "1:200"})
+
+	api, _ = NewClient("test1234")
+	api.Use(func(next RoundTrip) RoundTrip {
+		return func(r *APIRequest) (*APIResponse, error) {
+			r.Headers["X-Test-Error"] = "1"
+			return next(r)
+		}
+	})
+
+	_, err = api.GetNodes()
+	c.Assert(err, ErrorMatches, "API returned non-ok status code 418")
+	c.Assert(defaultHeaders, HasLen, 1)
+
+	api, _ = NewClient("test1234")
+	api.Use(func(next RoundTrip) RoundTrip {
+		return func(r *APIRequest) (*APIResponse, error) {
+			return nil, fmt.Errorf("Fault")
+		}
+	})
+
+	_, err = api.GetNodes()
+	c.Assert(err, ErrorMatches, "Fault")
+
+	api, _ = NewClient("test1234")
+	api.Use(func(next RoundTrip) RoundTrip {
+		return func(r *APIRequest) (*APIResponse, error) {
+			return nil, nil
+		}
+	})
+
+	_, err = api.GetNodes()
+	c.Assert(err, Equals, ErrNilResponse)
+
+	api, _ = NewClient("test1234")
+	api.Use(func(next RoundTrip) RoundTrip {
+		return func(r *APIRequest) (*APIResponse, error) {
+			return &APIResponse{StatusCode: 200, Body: []byte(`["127.0.0.1"]`)}, nil
+		}
+	})
+
+	ips, err := api.GetNodesIPs()
+	c.Assert(err, IsNil)
+	c.Assert(ips, DeepEquals, []string{"127.0.0.1"})
+}
+
 func (s *UpdownSuite) TestAPINewClient(c *C) {
 	api, err := NewClient("")
 
@@ -498,6 +733,14 @@ func (s *UpdownSuite) TestAPIHTTPErrors(c *C) {
 	c.Assert(err, NotNil)
 	c.Assert(err, ErrorMatches, "API returned non-ok status code 503")
 
+	_, err = api.GetMetricsByTime("ngg8", MetricsOptions{})
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, "API returned non-ok status code 503")
+
+	_, err = api.GetMetricsByHost("ngg8", MetricsOptions{})
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, "API returned non-ok status code 503")
+
 	_, err = api.GetNodes()
 	c.Assert(err, NotNil)
 	c.Assert(err, ErrorMatches, "API returned non-ok status code 503")
@@ -521,6 +764,18 @@ func (s *UpdownSuite) TestAPIHTTPErrors(c *C) {
 	_, err = api.GetStatusPages()
 	c.Assert(err, NotNil)
 	c.Assert(err, ErrorMatches, "API returned non-ok status code 503")
+
+	_, err = api.CreateCheck(CheckOptions{URL: "https://domain.com"})
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, "API returned non-ok status code 503")
+
+	_, err = api.UpdateCheck("ngg8", CheckOptions{})
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, "API returned non-ok status code 503")
+
+	err = api.DeleteCheck("ngg8")
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, "API returned non-ok status code 503")
 }
 
 func (s *UpdownSuite) TestAPIDataErrors(c *C) {
@@ -545,6 +800,14 @@ func (s *UpdownSuite) TestAPIDataErrors(c *C) {
 	c.Assert(err, NotNil)
 	c.Assert(err, ErrorMatches, "Can't decode API response: invalid character 'F' looking for beginning of value")
 
+	_, err = api.GetMetricsByTime("ngg8", MetricsOptions{})
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, "Can't decode API response: invalid character 'F' looking for beginning of value")
+
+	_, err = api.GetMetricsByHost("ngg8", MetricsOptions{})
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, "Can't decode API response: invalid character 'F' looking for beginning of value")
+
 	_, err = api.GetNodes()
 	c.Assert(err, NotNil)
 	c.Assert(err, ErrorMatches, "Can't decode API response: invalid character 'F' looking for beginning of value")
@@ -568,6 +831,57 @@ func (s *UpdownSuite) TestAPIDataErrors(c *C) {
 	_, err = api.GetStatusPages()
 	c.Assert(err, NotNil)
 	c.Assert(err, ErrorMatches, "Can't decode API response: invalid character 'F' looking for beginning of value")
+
+	_, err = api.CreateCheck(CheckOptions{URL: "https://domain.com"})
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, "Can't decode API response: invalid character 'F' looking for beginning of value")
+
+	_, err = api.UpdateCheck("ngg8", CheckOptions{})
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, "Can't decode API response: invalid character 'F' looking for beginning of value")
+
+	err = api.DeleteCheck("ngg8")
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, "Can't decode API response: invalid character 'F' looking for beginning of value")
+}
+
+func (s *UpdownSuite) TestCheckWrites(c *C) {
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+	c.Assert(api, NotNil)
+
+	enabled, mute := false, "recovery"
+
+	check, err := api.CreateCheck(CheckOptions{
+		URL:               "https://domain.com",
+		Alias:             "Domain",
+		Period:            60,
+		IsEnabled:         &enabled,
+		MuteUntil:         &mute,
+		DisabledLocations: []string{"syd"},
+		CustomHeaders:     map[string]string{"X-Test": "1"},
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(check, NotNil)
+	c.Assert(check.Token, Equals, "ngg8")
+	c.Assert(check.URL, Equals, "https://domain.com")
+	c.Assert(check.Alias, Equals, "Domain")
+	c.Assert(check.Period, Equals, 60)
+	c.Assert(check.IsEnabled, Equals, false)
+	c.Assert(check.DisabledLocations, DeepEquals, []string{"syd"})
+	c.Assert(check.CustomHeaders, DeepEquals, map[string]string{"X-Test": "1"})
+
+	check, err = api.UpdateCheck("ngg8", CheckOptions{Alias: "Updown"})
+
+	c.Assert(err, IsNil)
+	c.Assert(check, NotNil)
+	c.Assert(check.Alias, Equals, "Updown")
+	c.Assert(check.IsEnabled, Equals, true)
+
+	c.Assert(api.DeleteCheck("ngg8"), IsNil)
+	c.Assert(api.DeleteCheck("xyz1"), Equals, ErrNotDeleted)
 }
 
 func (s *UpdownSuite) TestGetChecks(c *C) {
@@ -735,6 +1049,256 @@ func (s *UpdownSuite) TestGetMetrics(c *C) {
 	c.Assert(metrics.Requests.ByResponseTime.Under32k, Equals, 0)
 }
 
+func (s *UpdownSuite) TestGetMetricsByTime(c *C) {
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+	c.Assert(api, NotNil)
+
+	series, err := api.GetMetricsByTime("ngg8", MetricsOptions{
+		From: time.Now().Add(-24 * time.Hour),
+		To:   time.Now(),
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(series, HasLen, 3)
+
+	c.Assert(series[0].Time.Unix(), Equals, int64(1737579600))
+	c.Assert(series[0].Metrics.Apdex, Equals, 0.98)
+	c.Assert(series[0].Metrics.Requests.Samples, Equals, 240)
+	c.Assert(series[1].Time.Unix(), Equals, int64(1737583200))
+	c.Assert(series[1].Metrics.Apdex, Equals, 0.99)
+	c.Assert(series[2].Time.Unix(), Equals, int64(1737586800))
+	c.Assert(series[2].Metrics.Apdex, Equals, 1.0)
+
+	c.Assert(series.Get(time.Unix(1737583200+1800, 0)), NotNil)
+	c.Assert(series.Get(time.Unix(1737583200+1800, 0)).Apdex, Equals, 0.99)
+	c.Assert(series.Get(time.Unix(0, 0)), IsNil)
+
+	var ms MetricsSeries
+
+	c.Assert(ms.UnmarshalJSON([]byte(`null`)), IsNil)
+	c.Assert(ms.UnmarshalJSON([]byte(`ABCD`)), NotNil)
+	c.Assert(ms.UnmarshalJSON([]byte(`{"2025-01-22K21:00:00Z": {}}`)), NotNil)
+}
+
+func (s *UpdownSuite) TestGetMetricsByHost(c *C) {
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+	c.Assert(api, NotNil)
+
+	metrics, err := api.GetMetricsByHost("ngg8", MetricsOptions{})
+
+	c.Assert(err, IsNil)
+	c.Assert(metrics, HasLen, 2)
+	c.Assert(metrics["fra"], NotNil)
+	c.Assert(metrics["fra"].Uptime, Equals, 100.0)
+	c.Assert(metrics["fra"].Timings.Total, Equals, 120)
+	c.Assert(metrics["syd"], NotNil)
+	c.Assert(metrics["syd"].Uptime, Equals, 99.5)
+
+	nodes, err := api.GetNodes()
+
+	c.Assert(err, IsNil)
+
+	joined := metrics.Join(nodes)
+
+	c.Assert(joined, HasLen, 2)
+	c.Assert(joined[0].Name, Equals, "fra")
+	c.Assert(joined[0].Node, NotNil)
+	c.Assert(joined[0].Node.City, Equals, "Frankfurt")
+	c.Assert(joined[0].Metrics.Apdex, Equals, 1.0)
+	c.Assert(joined[1].Name, Equals, "syd")
+	c.Assert(joined[1].Node.City, Equals, "Sydney")
+}
+
+func (s *UpdownSuite) TestGetMetricsLongRange(c *C) {
+	api, err := NewClient("test1234")
+
+	c.Assert(err, IsNil)
+	c.Assert(api, NotNil)
+
+	from := time.Now().Add(-70 * 24 * time.Hour)
+
+	metrics, err := api.GetMetrics("ngg8", MetricsOptions{From: from})
+
+	c.Assert(err, IsNil)
+	c.Assert(metrics, NotNil)
+	c.Assert(api.Calls(), Equals, uint(3))
+	c.Assert(metrics.Uptime > 99.998 && metrics.Uptime < 100, Equals, true)
+	c.Assert(metrics.Requests.Samples, Equals, 87441*3)
+	c.Assert(metrics.Requests.ByResponseTime.Under125, Equals, 70521*3)
+	c.Assert(metrics.Timings.Total, Equals, 370)
+
+	series, err := api.GetMetricsByTime("ngg8", MetricsOptions{From: from})
+
+	c.Assert(err, IsNil)
+	c.Assert(series, HasLen, 3)
+	c.Assert(series[0].Metrics.Requests.Samples, Equals, 720)
+	c.Assert(series[0].Metrics.Apdex > 0.979 && series[0].Metrics.Apdex < 0.981, Equals, true)
+
+	hosts, err := api.GetMetricsByHost("ngg8", MetricsOptions{From: from, GroupBy: GROUP_BY_TIME})
+
+	c.Assert(err, IsNil)
+	c.Assert(hosts, HasLen, 2)
+	c.Assert(hosts["syd"].Uptime, Equals, 99.5)
+	c.Assert(hosts["syd"].Timings.Total, Equals, 480)
+
+	_, err = api.GetMetrics("ngg8", MetricsOptions{From: time.Now(), To: from})
+	c.Assert(err, Equals, ErrInvalidMetricsRange)
+
+	_, err = api.GetMetricsByTime("ngg8", MetricsOptions{From: time.Now(), To: from})
+	c.Assert(err, Equals, ErrInvalidMetricsRange)
+
+	_, err = api.GetMetricsByHost("ngg8", MetricsOptions{From: time.Now(), To: from})
+	c.Assert(err, Equals, ErrInvalidMetricsRange)
+}
+
+func (s *UpdownSuite) TestMetricsOptions(c *C) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	c.Assert(MetricsOptions{}.Validate(), IsNil)
+	c.Assert(MetricsOptions{From: to, To: from}.Validate(), Equals, ErrInvalidMetricsRange)
+	c.Assert(MetricsOptions{GroupBy: "abcd"}.Validate(), Equals, ErrInvalidMetricsGroup)
+	c.Assert(MetricsOptions{From: time.Now().Add(time.Hour)}.Validate(), Equals, ErrFutureMetricsRange)
+	c.Assert(MetricsOptions{From: from, To: to, GroupBy: GROUP_BY_HOST}.Validate(), IsNil)
+
+	c.Assert(MetricsOptions{}.toQuery(), IsNil)
+	c.Assert(MetricsOptions{To: to}.toQuery(), DeepEquals, req.Query{"to": "2025-04-01T00:00:00+00:00"})
+	c.Assert(MetricsOptions{From: from}.toQuery(), DeepEquals, req.Query{"from": "2025-01-01T00:00:00+00:00"})
+
+	c.Assert(MetricsOptions{To: to}.split(METRICS_MAX_RANGE), HasLen, 1)
+	c.Assert(MetricsOptions{From: from, To: from.Add(METRICS_MAX_RANGE)}.split(METRICS_MAX_RANGE), HasLen, 1)
+	c.Assert(MetricsOptions{From: from, To: to}.split(7*24*time.Hour), HasLen, 13)
+
+	opts := MetricsOptions{From: from, To: to, GroupBy: GROUP_BY_TIME}.split(METRICS_MAX_RANGE)
+
+	c.Assert(opts, HasLen, 3)
+	c.Assert(opts[0].From, Equals, from)
+	c.Assert(opts[0].To, Equals, from.Add(METRICS_MAX_RANGE))
+	c.Assert(opts[1].From, Equals, from.Add(METRICS_MAX_RANGE))
+	c.Assert(opts[2].To, Equals, to)
+	c.Assert(opts[2].GroupBy, Equals, GROUP_BY_TIME)
+
+	api, _ := NewClient("test1234")
+
+	c.Assert(api.getMetricsMaxRange(), Equals, METRICS_MAX_RANGE)
+	api.SetMetricsMaxRange(24 * time.Hour)
+	c.Assert(api.getMetricsMaxRange(), Equals, 24*time.Hour)
+	api.SetMetricsMaxRange(-time.Hour)
+	c.Assert(api.getMetricsMaxRange(), Equals, METRICS_MAX_RANGE)
+
+	var nilClient *Client
+	nilClient.SetMetricsMaxRange(time.Hour)
+}
+
+func (s *UpdownSuite) TestMetricsRange(c *C) {
+	now := time.Date(2025, 3, 12, 15, 30, 0, 0, time.UTC)
+
+	o, err := parseMetricsRange("last 24h", now)
+	c.Assert(err, IsNil)
+	c.Assert(o.From, Equals, now.Add(-24*time.Hour))
+	c.Assert(o.To, Equals, now)
+
+	o, err = parseMetricsRange(" Last 1w2d ", now)
+	c.Assert(err, IsNil)
+	c.Assert(o.From, Equals, now.Add(-9*24*time.Hour))
+
+	o, err = parseMetricsRange("last 6", now)
+	c.Assert(err, IsNil)
+	c.Assert(o.From, Equals, now.Add(-6*time.Hour))
+
+	o, err = parseMetricsRange("today", now)
+	c.Assert(err, IsNil)
+	c.Assert(o.From, Equals, time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC))
+	c.Assert(o.To, Equals, now)
+
+	o, err = parseMetricsRange("yesterday", now)
+	c.Assert(err, IsNil)
+	c.Assert(o.From, Equals, time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC))
+	c.Assert(o.To, Equals, time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC))
+
+	o, err = parseMetricsRange("this week", now)
+	c.Assert(err, IsNil)
+	c.Assert(o.From, Equals, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+	c.Assert(o.To, Equals, now)
+
+	o, err = parseMetricsRange("last week", now)
+	c.Assert(err, IsNil)
+	c.Assert(o.From, Equals, time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC))
+	c.Assert(o.To, Equals, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	o, err = parseMetricsRange("this month", now)
+	c.Assert(err, IsNil)
+	c.Assert(o.From, Equals, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(o.To, Equals, now)
+
+	o, err = parseMetricsRange("last month", now)
+	c.Assert(err, IsNil)
+	c.Assert(o.From, Equals, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(o.To, Equals, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	_, err = parseMetricsRange("next month", now)
+	c.Assert(err, ErrorMatches, `Unsupported metrics range "next month"`)
+
+	_, err = parseMetricsRange("last 1y", now)
+	c.Assert(err, NotNil)
+
+	_, err = parseMetricsRange("last 0h", now)
+	c.Assert(err, ErrorMatches, `Invalid metrics range "last 0h"`)
+
+	o, err = MetricsRange("last 24h")
+	c.Assert(err, IsNil)
+	c.Assert(o.To.Sub(o.From), Equals, 24*time.Hour)
+}
+
+func (s *UpdownSuite) TestMetricsMerge(c *C) {
+	m := mergeMetrics([]*Metrics{{Uptime: 100, Apdex: 1}, {Uptime: 99, Apdex: 0.9}, nil})
+
+	c.Assert(m.Uptime, Equals, 99.5)
+	c.Assert(m.Apdex, Equals, 0.95)
+	c.Assert(m.Timings, IsNil)
+	c.Assert(m.Requests, IsNil)
+
+	m = mergeMetrics([]*Metrics{
+		{Uptime: 100, Timings: &TimingStats{Total: 100}, Requests: &RequestStats{Samples: 300}},
+		{Uptime: 96, Timings: &TimingStats{Total: 500}, Requests: &RequestStats{Samples: 100}},
+	})
+
+	c.Assert(m.Uptime, Equals, 99.0)
+	c.Assert(m.Timings.Total, Equals, 200)
+	c.Assert(m.Requests.Samples, Equals, 400)
+
+	// Chunks without samples info must not break weighting
+	m = mergeMetrics([]*Metrics{
+		{Uptime: 100, Timings: &TimingStats{Total: 100}, Requests: &RequestStats{Samples: 300}},
+		{Uptime: 96, Timings: &TimingStats{Total: 500}, Requests: &RequestStats{Samples: 0}},
+		{Uptime: 98, Timings: &TimingStats{Total: 300}},
+	})
+
+	c.Assert(m.Uptime, Equals, 98.0)
+	c.Assert(m.Timings.Total, Equals, 300)
+	c.Assert(m.Requests.Samples, Equals, 300)
+
+	// Apdex is recalculated only if all metrics have counters
+	m = mergeMetrics([]*Metrics{
+		{Apdex: 1, Requests: &RequestStats{Samples: 100, Satisfied: 100}},
+		{Apdex: 0, Requests: &RequestStats{Samples: 100}},
+		{Apdex: 0.5, Requests: &RequestStats{Samples: 200, Tolerated: 200}},
+	})
+
+	c.Assert(m.Apdex, Equals, 0.5)
+
+	m = mergeMetrics([]*Metrics{
+		{Apdex: 1, Requests: &RequestStats{Samples: 100, Satisfied: 100}},
+		{Apdex: 0.5, Requests: &RequestStats{Samples: 300}},
+	})
+
+	c.Assert(m.Apdex, Equals, 0.625)
+}
+
 func (s *UpdownSuite) TestGetNodes(c *C) {
 	api, err := NewClient("test1234")
 
@@ -859,6 +1423,27 @@ func (s *UpdownSuite) TestPulse(c *C) {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+type testInstrumentation struct {
+	requests []*RequestInfo
+}
+
+func (i *testInstrumentation) RequestDone(info *RequestInfo) {
+	i.requests = append(i.requests, info)
+}
+
+type testCtxKey string
+
+type testTracer struct {
+	testInstrumentation
+}
+
+func (i *testTracer) RequestStart(ctx context.Context, info *RequestInfo, headers req.Headers) context.Context {
+	headers["traceparent"] = info.Endpoint
+	return context.WithValue(ctx, testCtxKey("started"), true)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 func handlerChecks(rw http.ResponseWriter, r *http.Request) {
 	if r.Header.Get("X-API-Key") == "http-error" {
 		rw.WriteHeader(503)
@@ -914,6 +1499,62 @@ func handlerChecks(rw http.ResponseWriter, r *http.Request) {
 ]`))
 }
 
+func handlerCheckWrite(rw http.ResponseWriter, r *http.Request) {
+	if writeErrorResponse(rw, r) {
+		return
+	}
+
+	options := &CheckOptions{}
+
+	if json.NewDecoder(r.Body).Decode(options) != nil {
+		rw.WriteHeader(400)
+		return
+	}
+
+	check := &Check{
+		Token:             "ngg8",
+		URL:               options.URL,
+		Alias:             options.Alias,
+		Period:            options.Period,
+		IsEnabled:         true,
+		DisabledLocations: options.DisabledLocations,
+		CustomHeaders:     options.CustomHeaders,
+	}
+
+	if options.IsEnabled != nil {
+		check.IsEnabled = *options.IsEnabled
+	}
+
+	if options.MuteUntil != nil {
+		mute, _ := time.Parse(time.RFC3339, *options.MuteUntil)
+		check.MuteUntil = Date{mute}
+	}
+
+	data, _ := json.Marshal(check)
+
+	if r.Method == http.MethodPost {
+		rw.WriteHeader(201)
+	} else {
+		rw.WriteHeader(200)
+	}
+
+	rw.Write(data)
+}
+
+func handlerCheckDelete(rw http.ResponseWriter, r *http.Request) {
+	if writeErrorResponse(rw, r) {
+		return
+	}
+
+	rw.WriteHeader(200)
+
+	if strings.HasSuffix(r.URL.Path, "/ngg8") {
+		rw.Write([]byte(`{"deleted": true}`))
+	} else {
+		rw.Write([]byte(`{"deleted": false}`))
+	}
+}
+
 func handlerCheck(rw http.ResponseWriter, r *http.Request) {
 	if writeErrorResponse(rw, r) {
 		return
@@ -993,6 +1634,25 @@ func handlerMetrics(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	switch r.URL.Query().Get("group") {
+	case "time":
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{
+  "2025-01-22T23:00:00Z": { "uptime": 100, "apdex": 1, "requests": { "samples": 240 } },
+  "2025-01-22T21:00:00Z": { "uptime": 100, "apdex": 0.98, "requests": { "samples": 240 } },
+  "2025-01-22T22:00:00Z": { "uptime": 100, "apdex": 0.99, "requests": { "samples": 240 } }
+}`))
+		return
+
+	case "host":
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{
+  "fra": { "uptime": 100, "apdex": 1, "timings": { "total": 120 } },
+  "syd": { "uptime": 99.5, "apdex": 0.9, "timings": { "total": 480 } }
+}`))
+		return
+	}
+
 	rw.WriteHeader(200)
 	rw.Write([]byte(`{
   "uptime": 99.999,
@@ -1240,6 +1900,13 @@ func handlerStatusPages(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	rw.Header().Set("ETag", `W/"3ji4k"`)
+
+	if r.Header.Get("If-None-Match") == `W/"3ji4k"` {
+		rw.WriteHeader(304)
+		return
+	}
+
 	rw.WriteHeader(200)
 	rw.Write([]byte(`[
   {
@@ -1259,11 +1926,21 @@ func handlerPulse(rw http.ResponseWriter, r *http.Request) {
 	rw.Write([]byte(`OK: ac0607d2-3138-401f-8229-6ca473d03472`))
 }
 
+func handlerPulseInvalid(rw http.ResponseWriter, r *http.Request) {
+	rw.WriteHeader(200)
+	rw.Write([]byte(`<html>Maintenance</html>`))
+}
+
 func handlerPulseError(rw http.ResponseWriter, r *http.Request) {
 	rw.WriteHeader(404)
 }
 
 func writeErrorResponse(rw http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("X-Test-Error") != "" {
+		rw.WriteHeader(418)
+		return true
+	}
+
 	if r.Header.Get("X-API-Key") == "http-error" {
 		rw.WriteHeader(503)
 		return true
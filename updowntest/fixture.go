@@ -0,0 +1,377 @@
+package updowntest
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// REDACTED is value used instead of secrets in fixtures
+const REDACTED = "[REDACTED]"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Fixture contains recorded API interactions
+type Fixture struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction contains recorded request and response
+type Interaction struct {
+	Request  *FixtureRequest  `json:"request"`
+	Response *FixtureResponse `json:"response"`
+}
+
+// FixtureRequest contains info about recorded request
+type FixtureRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	FixtureBody
+}
+
+// FixtureResponse contains info about recorded response
+type FixtureResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	FixtureBody
+}
+
+// FixtureBody contains recorded body. Valid JSON is stored as is, any other
+// data (e.g. text or compressed data) is stored as base64-encoded string.
+type FixtureBody struct {
+	Body    json.RawMessage `json:"body,omitempty"`
+	RawBody []byte          `json:"raw_body,omitempty"`
+}
+
+// Recorder is HTTP transport which records all requests and responses
+type Recorder struct {
+	// Transport is transport used for sending requests. If not set,
+	// http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	file         string
+	mu           sync.Mutex
+	interactions []*Interaction
+}
+
+// Replayer is HTTP transport which serves responses from fixture file
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	ErrEmptyFixtureFile = errors.New("Fixture file path is empty")
+	ErrNoInteraction    = errors.New("There is no recorded interaction for request")
+)
+
+// secretHeaders is slice with names of headers with secrets
+var secretHeaders = []string{"X-API-Key", "API-Key", "Authorization", "Cookie", "Set-Cookie"}
+
+// secretParams is slice with names of query parameters with secrets
+var secretParams = []string{"api-key"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewRecorder creates new recorder which saves interactions to given file
+func NewRecorder(file string) *Recorder {
+	return &Recorder{file: file}
+}
+
+// NewReplayer creates new replayer with interactions from given file
+func NewReplayer(file string) (*Replayer, error) {
+	fixture, err := ReadFixture(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Replayer{
+		interactions: fixture.Interactions,
+		used:         make([]bool, len(fixture.Interactions)),
+	}, nil
+}
+
+// ReadFixture reads fixture from file
+func ReadFixture(file string) (*Fixture, error) {
+	if file == "" {
+		return nil, ErrEmptyFixtureFile
+	}
+
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't read fixture: %w", err)
+	}
+
+	fixture := &Fixture{}
+	err = json.Unmarshal(data, fixture)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode fixture: %w", err)
+	}
+
+	return fixture, nil
+}
+
+// NewFixtureBody creates new fixture body from given data
+func NewFixtureBody(data []byte) FixtureBody {
+	switch {
+	case len(data) == 0:
+		return FixtureBody{}
+	case json.Valid(data):
+		return FixtureBody{Body: data}
+	}
+
+	return FixtureBody{RawBody: data}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Bytes returns body data. JSON body is returned in compact form, because
+// fixture file can be reformatted.
+func (b FixtureBody) Bytes() []byte {
+	if b.RawBody != nil {
+		return b.RawBody
+	}
+
+	buf := &bytes.Buffer{}
+
+	if json.Compact(buf, b.Body) != nil {
+		return b.Body
+	}
+
+	return buf.Bytes()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Client returns HTTP client which uses recorder as transport
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip sends request and records interaction
+func (r *Recorder) RoundTrip(hr *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	var err error
+
+	if hr.Body != nil {
+		reqBody, err = io.ReadAll(hr.Body)
+		hr.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		hr.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	transport := r.Transport
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(hr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, &Interaction{
+		Request: &FixtureRequest{
+			Method:      hr.Method,
+			URL:         redactURL(hr.URL),
+			Headers:     redactHeaders(hr.Header),
+			FixtureBody: NewFixtureBody(reqBody),
+		},
+		Response: &FixtureResponse{
+			StatusCode:  resp.StatusCode,
+			Headers:     redactHeaders(resp.Header),
+			FixtureBody: NewFixtureBody(respBody),
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Interactions returns number of recorded interactions
+func (r *Recorder) Interactions() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.interactions)
+}
+
+// Save saves all recorded interactions to fixture file
+func (r *Recorder) Save() error {
+	if r.file == "" {
+		return ErrEmptyFixtureFile
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(&Fixture{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("Can't encode fixture: %w", err)
+	}
+
+	err = os.WriteFile(r.file, append(data, '\n'), 0644)
+
+	if err != nil {
+		return fmt.Errorf("Can't save fixture: %w", err)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Client returns HTTP client which uses replayer as transport
+func (r *Replayer) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip returns recorded response for request. Interactions with the same
+// request are returned in the order they were recorded.
+func (r *Replayer) RoundTrip(hr *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if hr.Body != nil {
+		body, _ = io.ReadAll(hr.Body)
+		hr.Body.Close()
+	}
+
+	key := getRequestKey(hr.Method, redactURL(hr.URL), NewFixtureBody(body).Bytes())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.interactions {
+		if r.used[i] || in.Request == nil || in.Response == nil {
+			continue
+		}
+
+		if getRequestKey(in.Request.Method, in.Request.URL, in.Request.Bytes()) != key {
+			continue
+		}
+
+		r.used[i] = true
+
+		header := in.Response.Headers.Clone()
+		body := in.Response.Bytes()
+
+		if header == nil {
+			header = http.Header{}
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       hr,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w %s %s", ErrNoInteraction, hr.Method, hr.URL.RequestURI())
+}
+
+// Remaining returns number of interactions which weren't replayed yet
+func (r *Replayer) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var result int
+
+	for _, used := range r.used {
+		if !used {
+			result++
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// redactHeaders returns copy of headers with redacted secrets
+func redactHeaders(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+
+	result := h.Clone()
+
+	for _, name := range secretHeaders {
+		if result.Get(name) != "" {
+			result.Set(name, REDACTED)
+		}
+	}
+
+	return result
+}
+
+// redactURL returns URL with redacted secrets and sorted query
+func redactURL(u *url.URL) string {
+	uu := *u
+	query := uu.Query()
+
+	for _, name := range secretParams {
+		if query.Has(name) {
+			query.Set(name, REDACTED)
+		}
+	}
+
+	uu.RawQuery = query.Encode()
+
+	return uu.String()
+}
+
+// getRequestKey returns key used for matching requests. Scheme and host are
+// ignored, so fixtures can be replayed with any API URL.
+func getRequestKey(method, rawURL string, body []byte) string {
+	u, err := url.Parse(rawURL)
+
+	if err != nil {
+		return method + " " + rawURL + " " + string(body)
+	}
+
+	return method + " " + u.Path + "?" + u.Query().Encode() + " " + string(body)
+}
@@ -0,0 +1,160 @@
+package updowntest
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"github.com/essentialkaos/updown"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdowntestSuite) TestRecordReplay(c *C) {
+	file := c.MkDir() + "/fixture.json"
+
+	srv := NewServer()
+	token := srv.AddCheck(&updown.Check{URL: "https://domain.com", Alias: "Test"})
+	srv.AddDowntime(token, &updown.Downtime{Error: "500"})
+
+	rec := NewRecorder(file)
+	client := srv.Client()
+	client.SetHTTPClient(rec.Client())
+
+	checks, err := client.GetChecks()
+	c.Assert(err, IsNil)
+	c.Assert(checks, HasLen, 1)
+
+	_, err = client.UpdateCheck(token, updown.CheckOptions{Alias: "Test1"})
+	c.Assert(err, IsNil)
+
+	checks, err = client.GetChecks()
+	c.Assert(err, IsNil)
+	c.Assert(checks[0].Alias, Equals, "Test1")
+
+	_, err = client.GetDowntimes(token, true)
+	c.Assert(err, IsNil)
+
+	c.Assert(rec.Interactions(), Equals, 4)
+	c.Assert(rec.Save(), IsNil)
+
+	srv.Close()
+
+	data, err := os.ReadFile(file)
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(string(data), DEFAULT_API_KEY), Equals, false)
+	c.Assert(strings.Contains(string(data), REDACTED), Equals, true)
+
+	rep, err := NewReplayer(file)
+	c.Assert(err, IsNil)
+
+	client, _ = updown.NewClient("other-key")
+	client.SetURL("https://example.com/api")
+	client.SetHTTPClient(rep.Client())
+
+	checks, err = client.GetChecks()
+	c.Assert(err, IsNil)
+	c.Assert(checks[0].Alias, Equals, "Test")
+
+	_, err = client.UpdateCheck(token, updown.CheckOptions{Alias: "Test1"})
+	c.Assert(err, IsNil)
+
+	checks, err = client.GetChecks()
+	c.Assert(err, IsNil)
+	c.Assert(checks[0].Alias, Equals, "Test1")
+
+	downtimes, err := client.GetDowntimes(token, true)
+	c.Assert(err, IsNil)
+	c.Assert(downtimes, HasLen, 1)
+
+	c.Assert(rep.Remaining(), Equals, 0)
+
+	_, err = client.GetChecks()
+	c.Assert(errors.Is(err, ErrNoInteraction), Equals, true)
+}
+
+func (s *UpdowntestSuite) TestRecordReplayBinary(c *C) {
+	file := c.MkDir() + "/fixture.json"
+	payload := []byte{0x1f, 0x8b, 0x08, 0x00, 0xff, 0xfe, 0x00, 0x80}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rw.Header().Set("Content-Encoding", "gzip")
+		rw.Write(append(body, payload...))
+	}))
+
+	defer srv.Close()
+
+	// Transport decompresses responses only if Accept-Encoding header isn't set
+	hr, _ := http.NewRequest("POST", srv.URL+"/data", bytes.NewReader(payload))
+	hr.Header.Set("Accept-Encoding", "gzip")
+
+	rec := NewRecorder(file)
+	resp, err := rec.Client().Do(hr)
+
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+
+	c.Assert(rec.Save(), IsNil)
+
+	fixture, err := ReadFixture(file)
+
+	c.Assert(err, IsNil)
+	c.Assert(fixture.Interactions, HasLen, 1)
+	c.Assert(fixture.Interactions[0].Request.RawBody, DeepEquals, payload)
+	c.Assert(fixture.Interactions[0].Response.Body, IsNil)
+
+	rep, err := NewReplayer(file)
+	c.Assert(err, IsNil)
+
+	hr, _ = http.NewRequest("POST", "https://example.com/data", bytes.NewReader(payload))
+	resp, err = rep.Client().Do(hr)
+	c.Assert(err, IsNil)
+
+	body, _ := io.ReadAll(resp.Body)
+	c.Assert(body, DeepEquals, append(append([]byte{}, payload...), payload...))
+	c.Assert(resp.Header.Get("Content-Encoding"), Equals, "gzip")
+
+	c.Assert(NewFixtureBody(nil), DeepEquals, FixtureBody{})
+	c.Assert(NewFixtureBody([]byte("{\n  \"a\": 1\n}")).Bytes(), DeepEquals, []byte(`{"a":1}`))
+	c.Assert(NewFixtureBody([]byte("OK")).Bytes(), DeepEquals, []byte("OK"))
+	c.Assert(FixtureBody{Body: []byte("{")}.Bytes(), DeepEquals, []byte("{"))
+}
+
+func (s *UpdowntestSuite) TestFixtureErrors(c *C) {
+	dir := c.MkDir()
+
+	_, err := NewReplayer("")
+	c.Assert(err, Equals, ErrEmptyFixtureFile)
+
+	_, err = NewReplayer(dir + "/unknown.json")
+	c.Assert(err, ErrorMatches, "Can't read fixture: .*")
+
+	os.WriteFile(dir+"/broken.json", []byte("{"), 0644)
+
+	_, err = NewReplayer(dir + "/broken.json")
+	c.Assert(err, ErrorMatches, "Can't decode fixture: .*")
+
+	c.Assert(NewRecorder("").Save(), Equals, ErrEmptyFixtureFile)
+	c.Assert(NewRecorder(dir+"/unknown/fixture.json").Save(), ErrorMatches, "Can't save fixture: .*")
+
+	client, _ := updown.NewClient("test")
+	client.SetURL("http://127.0.0.1:1")
+	client.SetHTTPClient(NewRecorder(dir + "/fixture.json").Client())
+
+	_, err = client.GetChecks()
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,1096 @@
+// Package updowntest provides fake updown.io API server and record/replay HTTP
+// transports for testing
+package updowntest
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/essentialkaos/updown"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_API_KEY is default API key accepted by server
+const DEFAULT_API_KEY = "updowntest"
+
+// ROUTE_ALL is route used for injecting errors to all requests
+const ROUTE_ALL = "*"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Server is fake updown.io API server
+type Server struct {
+	// URL is base URL of API
+	URL string
+
+	// APIKey is API key accepted by server
+	APIKey string
+
+	srv *httptest.Server
+
+	mu          sync.Mutex
+	checks      updown.Checks
+	downtimes   map[string]updown.Downtimes
+	metrics     map[string]*updown.Metrics
+	series      map[string]updown.MetricsSeries
+	hosts       map[string]updown.HostsMetrics
+	nodes       updown.Nodes
+	recipients  updown.Recipients
+	statusPages updown.StatusPages
+	pulses      []*Pulse
+	errors      map[string]int
+	latency     time.Duration
+	webhookURL  string
+	requests    int
+}
+
+// Pulse contains info about received pulse request
+type Pulse struct {
+	Token   string
+	Key     string
+	UUID    string
+	Payload string
+	Time    time.Time
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	// ErrUnknownCheck is returned if there is no check with given token
+	ErrUnknownCheck = errors.New("Unknown check")
+
+	// ErrEmptyWebhookURL is returned if webhook URL is not set
+	ErrEmptyWebhookURL = errors.New("Webhook URL is not set")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewServer creates and starts new fake server. Server must be closed after
+// usage.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:    DEFAULT_API_KEY,
+		downtimes: map[string]updown.Downtimes{},
+		metrics:   map[string]*updown.Metrics{},
+		series:    map[string]updown.MetricsSeries{},
+		hosts:     map[string]updown.HostsMetrics{},
+		nodes:     updown.Nodes{},
+		errors:    map[string]int{},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/checks", s.api(s.handleGetChecks))
+	mux.HandleFunc("POST /api/checks", s.api(s.handleCreateCheck))
+	mux.HandleFunc("GET /api/checks/{token}", s.api(s.handleGetCheck))
+	mux.HandleFunc("PUT /api/checks/{token}", s.api(s.handleUpdateCheck))
+	mux.HandleFunc("DELETE /api/checks/{token}", s.api(s.handleDeleteCheck))
+	mux.HandleFunc("GET /api/checks/{token}/downtimes", s.api(s.handleGetDowntimes))
+	mux.HandleFunc("GET /api/checks/{token}/metrics", s.api(s.handleGetMetrics))
+	mux.HandleFunc("GET /api/nodes", s.api(s.handleGetNodes))
+	mux.HandleFunc("GET /api/nodes/ips", s.api(s.handleGetIPs))
+	mux.HandleFunc("GET /api/nodes/ipv4", s.api(s.handleGetIPs))
+	mux.HandleFunc("GET /api/nodes/ipv6", s.api(s.handleGetIPs))
+	mux.HandleFunc("GET /api/recipients", s.api(s.handleGetRecipients))
+	mux.HandleFunc("POST /api/recipients", s.api(s.handleCreateRecipient))
+	mux.HandleFunc("PUT /api/recipients/{id}", s.api(s.handleUpdateRecipient))
+	mux.HandleFunc("DELETE /api/recipients/{id}", s.api(s.handleDeleteRecipient))
+	mux.HandleFunc("GET /api/status-pages", s.api(s.handleGetStatusPages))
+	mux.HandleFunc("POST /api/status-pages", s.api(s.handleCreateStatusPage))
+	mux.HandleFunc("PUT /api/status-pages/{token}", s.api(s.handleUpdateStatusPage))
+	mux.HandleFunc("DELETE /api/status-pages/{token}", s.api(s.handleDeleteStatusPage))
+	mux.HandleFunc("GET /pulse/{token}/{key}", s.handlePulse)
+	mux.HandleFunc("POST /pulse/{token}/{key}", s.handlePulse)
+
+	s.srv = httptest.NewServer(mux)
+	s.URL = s.srv.URL + "/api"
+
+	return s
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Close stops server
+func (s *Server) Close() {
+	if s == nil || s.srv == nil {
+		return
+	}
+
+	s.srv.Close()
+}
+
+// Client creates new API client configured for working with server
+func (s *Server) Client() *updown.Client {
+	client, _ := updown.NewClient(s.APIKey)
+	client.SetURL(s.URL)
+
+	return client
+}
+
+// PulseURL returns pulse URL for check with given token
+func (s *Server) PulseURL(token, key string) string {
+	return s.srv.URL + "/pulse/" + token + "/" + key
+}
+
+// Requests returns number of API requests handled by server
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// AddCheck adds check to server. If check has no token, random token will
+// be generated. Returns check token.
+func (s *Server) AddCheck(check *updown.Check) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := *check
+
+	if c.Token == "" {
+		c.Token = s.genToken()
+	}
+
+	s.checks = slices.DeleteFunc(s.checks, func(cc *updown.Check) bool {
+		return cc.Token == c.Token
+	})
+
+	s.checks = append(s.checks, &c)
+
+	return c.Token
+}
+
+// AddDowntime adds downtime to check with given token
+func (s *Server) AddDowntime(token string, downtime *updown.Downtime) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := *downtime
+	s.downtimes[token] = append(s.downtimes[token], &d)
+}
+
+// SetMetrics sets metrics returned for check with given token
+func (s *Server) SetMetrics(token string, metrics *updown.Metrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.metrics[token] = metrics
+}
+
+// SetMetricsByTime sets metrics grouped by time for check with given token
+func (s *Server) SetMetricsByTime(token string, series updown.MetricsSeries) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.series[token] = series
+}
+
+// SetMetricsByHost sets metrics grouped by host for check with given token
+func (s *Server) SetMetricsByHost(token string, hosts updown.HostsMetrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hosts[token] = hosts
+}
+
+// SetNodes sets monitoring nodes
+func (s *Server) SetNodes(nodes updown.Nodes) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nodes = nodes
+}
+
+// AddRecipient adds alert recipient
+func (s *Server) AddRecipient(recipient *updown.Recipient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := *recipient
+	s.recipients = append(s.recipients, &r)
+}
+
+// AddStatusPage adds status page
+func (s *Server) AddStatusPage(page *updown.StatusPage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := *page
+	s.statusPages = append(s.statusPages, &p)
+}
+
+// Recipient returns copy of recipient with given ID
+func (s *Server) Recipient(id string) *updown.Recipient {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.getRecipient(id)
+
+	if r == nil {
+		return nil
+	}
+
+	rr := *r
+
+	return &rr
+}
+
+// StatusPage returns copy of status page with given token
+func (s *Server) StatusPage(token string) *updown.StatusPage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.getStatusPage(token)
+
+	if p == nil {
+		return nil
+	}
+
+	pp := *p
+
+	return &pp
+}
+
+// Check returns copy of check with given token
+func (s *Server) Check(token string) *updown.Check {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.checks.Get(token)
+
+	if c == nil {
+		return nil
+	}
+
+	cc := *c
+
+	return &cc
+}
+
+// Pulses returns all pulses received for check with given token
+func (s *Server) Pulses(token string) []*Pulse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*Pulse
+
+	for _, p := range s.pulses {
+		if p.Token == token {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// InjectError makes server respond with given status code to requests with
+// given route (e.g. "/checks/:token", "DELETE /checks/:token" or ROUTE_ALL).
+// Status code 0 removes injected error.
+func (s *Server) InjectError(route string, statusCode int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if statusCode == 0 {
+		delete(s.errors, route)
+	} else {
+		s.errors[route] = statusCode
+	}
+}
+
+// ClearErrors removes all injected errors
+func (s *Server) ClearErrors() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errors = map[string]int{}
+}
+
+// SetLatency sets delay added to every request
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = latency
+}
+
+// SetWebhookURL sets URL which receives webhooks when check goes down or up
+func (s *Server) SetWebhookURL(url string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.webhookURL = url
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CheckDown marks check as down, starts new downtime and sends "check.down"
+// webhook (if webhook URL is set)
+func (s *Server) CheckDown(token, errMsg string) error {
+	s.mu.Lock()
+
+	check := s.checks.Get(token)
+
+	if check == nil {
+		s.mu.Unlock()
+		return ErrUnknownCheck
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	downtime := &updown.Downtime{
+		ID:        s.genID(),
+		Error:     errMsg,
+		StartedAt: updown.Date{Time: now},
+	}
+
+	check.IsDown = true
+	check.Error = errMsg
+	check.DownSince = updown.Date{Time: now}
+	check.UpSince = updown.Date{}
+
+	s.downtimes[token] = append(updown.Downtimes{downtime}, s.downtimes[token]...)
+
+	event := &updown.EventDown{
+		Event:    s.newEvent(updown.EVENT_DOWN, check, now),
+		Downtime: downtime,
+	}
+
+	webhookURL := s.webhookURL
+
+	s.mu.Unlock()
+
+	return sendWebhook(webhookURL, event)
+}
+
+// CheckUp marks check as up, finishes current downtime and sends "check.up"
+// webhook (if webhook URL is set)
+func (s *Server) CheckUp(token string) error {
+	s.mu.Lock()
+
+	check := s.checks.Get(token)
+
+	if check == nil {
+		s.mu.Unlock()
+		return ErrUnknownCheck
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	downtime := &updown.Downtime{}
+
+	if len(s.downtimes[token]) != 0 && s.downtimes[token][0].EndedAt.IsZero() {
+		downtime = s.downtimes[token][0]
+		downtime.EndedAt = updown.Date{Time: now}
+		downtime.Duration = int(now.Sub(downtime.StartedAt.Time).Seconds())
+	}
+
+	check.IsDown = false
+	check.Error = ""
+	check.DownSince = updown.Date{}
+	check.UpSince = updown.Date{Time: now}
+
+	event := &updown.EventUp{
+		Event:    s.newEvent(updown.EVENT_UP, check, now),
+		Downtime: downtime,
+	}
+
+	webhookURL := s.webhookURL
+
+	s.mu.Unlock()
+
+	return sendWebhook(webhookURL, event)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// api wraps API handler with common logic (auth, latency and errors)
+func (s *Server) api(handler func(rw http.ResponseWriter, r *http.Request) (any, int)) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		route := getRoute(r)
+
+		s.mu.Lock()
+		s.requests++
+		latency := s.latency
+		statusCode := s.getInjectedError(r.Method, route)
+		s.mu.Unlock()
+
+		if latency > 0 {
+			time.Sleep(latency)
+		}
+
+		switch {
+		case r.Header.Get("X-API-Key") != s.APIKey:
+			writeJSON(rw, 401, errorResponse("Invalid API key"))
+			return
+		case statusCode != 0:
+			writeJSON(rw, statusCode, errorResponse(http.StatusText(statusCode)))
+			return
+		}
+
+		// Response must be encoded while lock is held, because handlers
+		// may return server state
+		s.mu.Lock()
+		data, status := handler(rw, r)
+		body := encodeJSON(data)
+		s.mu.Unlock()
+
+		writeBody(rw, status, body)
+	}
+}
+
+// handleGetChecks is handler for GET /api/checks
+func (s *Server) handleGetChecks(rw http.ResponseWriter, r *http.Request) (any, int) {
+	result := updown.Checks{}
+
+	for _, c := range s.checks {
+		cc := *c
+		cc.Metrics = nil
+		result = append(result, &cc)
+	}
+
+	return result, 200
+}
+
+// handleGetCheck is handler for GET /api/checks/:token
+func (s *Server) handleGetCheck(rw http.ResponseWriter, r *http.Request) (any, int) {
+	check := s.checks.Get(r.PathValue("token"))
+
+	if check == nil {
+		return errorResponse("Not found"), 404
+	}
+
+	cc := *check
+	cc.Metrics = nil
+
+	if r.URL.Query().Get("metrics") == "true" {
+		cc.Metrics = s.metrics[check.Token]
+	}
+
+	return &cc, 200
+}
+
+// handleCreateCheck is handler for POST /api/checks
+func (s *Server) handleCreateCheck(rw http.ResponseWriter, r *http.Request) (any, int) {
+	options := updown.CheckOptions{}
+
+	if json.NewDecoder(r.Body).Decode(&options) != nil {
+		return errorResponse("Invalid request body"), 400
+	}
+
+	if options.URL == "" {
+		return errorResponse("URL is required"), 400
+	}
+
+	check := &updown.Check{
+		Token:     s.genToken(),
+		Period:    60,
+		Apdex:     0.5,
+		IsEnabled: true,
+		HTTPVerb:  "GET/HEAD",
+		CreatedAt: updown.Date{Time: time.Now().UTC().Truncate(time.Second)},
+	}
+
+	applyOptions(check, options)
+
+	s.checks = append(s.checks, check)
+
+	return check, 201
+}
+
+// handleUpdateCheck is handler for PUT /api/checks/:token
+func (s *Server) handleUpdateCheck(rw http.ResponseWriter, r *http.Request) (any, int) {
+	check := s.checks.Get(r.PathValue("token"))
+
+	if check == nil {
+		return errorResponse("Not found"), 404
+	}
+
+	options := updown.CheckOptions{}
+
+	if json.NewDecoder(r.Body).Decode(&options) != nil {
+		return errorResponse("Invalid request body"), 400
+	}
+
+	applyOptions(check, options)
+
+	return check, 200
+}
+
+// handleDeleteCheck is handler for DELETE /api/checks/:token
+func (s *Server) handleDeleteCheck(rw http.ResponseWriter, r *http.Request) (any, int) {
+	token := r.PathValue("token")
+
+	if s.checks.Get(token) == nil {
+		return errorResponse("Not found"), 404
+	}
+
+	s.checks = slices.DeleteFunc(s.checks, func(c *updown.Check) bool {
+		return c.Token == token
+	})
+
+	delete(s.downtimes, token)
+	delete(s.metrics, token)
+	delete(s.series, token)
+	delete(s.hosts, token)
+
+	return map[string]bool{"deleted": true}, 200
+}
+
+// handleGetDowntimes is handler for GET /api/checks/:token/downtimes
+func (s *Server) handleGetDowntimes(rw http.ResponseWriter, r *http.Request) (any, int) {
+	token := r.PathValue("token")
+
+	if s.checks.Get(token) == nil {
+		return errorResponse("Not found"), 404
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	page = max(page, 1)
+	detailed := r.URL.Query().Get("results") == "true"
+	downtimes := s.downtimes[token]
+	result := updown.Downtimes{}
+
+	for i := (page - 1) * 100; i < len(downtimes) && i < page*100; i++ {
+		d := *downtimes[i]
+
+		if !detailed {
+			d.DownResults, d.UpResults = nil, nil
+		}
+
+		result = append(result, &d)
+	}
+
+	return result, 200
+}
+
+// handleGetMetrics is handler for GET /api/checks/:token/metrics
+func (s *Server) handleGetMetrics(rw http.ResponseWriter, r *http.Request) (any, int) {
+	token := r.PathValue("token")
+
+	if s.checks.Get(token) == nil {
+		return errorResponse("Not found"), 404
+	}
+
+	switch updown.MetricsGroup(r.URL.Query().Get("group")) {
+	case updown.GROUP_BY_TIME:
+		result := map[string]*updown.Metrics{}
+
+		for _, p := range s.series[token] {
+			result[p.Time.UTC().Format(time.RFC3339)] = p.Metrics
+		}
+
+		return result, 200
+
+	case updown.GROUP_BY_HOST:
+		if s.hosts[token] == nil {
+			return updown.HostsMetrics{}, 200
+		}
+
+		return s.hosts[token], 200
+	}
+
+	if s.metrics[token] == nil {
+		return &updown.Metrics{}, 200
+	}
+
+	return s.metrics[token], 200
+}
+
+// handleGetNodes is handler for GET /api/nodes
+func (s *Server) handleGetNodes(rw http.ResponseWriter, r *http.Request) (any, int) {
+	return s.nodes, 200
+}
+
+// handleGetIPs is handler for GET /api/nodes/ips, /api/nodes/ipv4 and
+// /api/nodes/ipv6
+func (s *Server) handleGetIPs(rw http.ResponseWriter, r *http.Request) (any, int) {
+	result := []string{}
+	path := r.URL.Path
+
+	for _, name := range getNodesNames(s.nodes) {
+		node := s.nodes[name]
+
+		if node.IP != "" && !strings.HasSuffix(path, "/ipv6") {
+			result = append(result, node.IP)
+		}
+
+		if node.IPv6 != "" && !strings.HasSuffix(path, "/ipv4") {
+			result = append(result, node.IPv6)
+		}
+	}
+
+	return result, 200
+}
+
+// handleGetRecipients is handler for GET /api/recipients
+func (s *Server) handleGetRecipients(rw http.ResponseWriter, r *http.Request) (any, int) {
+	if s.recipients == nil {
+		return updown.Recipients{}, 200
+	}
+
+	return s.recipients, 200
+}
+
+// handleCreateRecipient is handler for POST /api/recipients
+func (s *Server) handleCreateRecipient(rw http.ResponseWriter, r *http.Request) (any, int) {
+	recipient := &updown.Recipient{}
+
+	if json.NewDecoder(r.Body).Decode(recipient) != nil {
+		return errorResponse("Invalid request body"), 400
+	}
+
+	if recipient.Type == "" || recipient.Value == "" {
+		return errorResponse("Type and value are required"), 400
+	}
+
+	recipient.ID = s.genRecipientID(recipient.Type)
+	s.recipients = append(s.recipients, recipient)
+
+	return recipient, 201
+}
+
+// handleUpdateRecipient is handler for PUT /api/recipients/:id
+func (s *Server) handleUpdateRecipient(rw http.ResponseWriter, r *http.Request) (any, int) {
+	recipient := s.getRecipient(r.PathValue("id"))
+
+	if recipient == nil {
+		return errorResponse("Not found"), 404
+	}
+
+	options := &updown.Recipient{}
+
+	if json.NewDecoder(r.Body).Decode(options) != nil {
+		return errorResponse("Invalid request body"), 400
+	}
+
+	if options.Name != "" {
+		recipient.Name = options.Name
+	}
+
+	if options.Value != "" {
+		recipient.Value = options.Value
+	}
+
+	return recipient, 200
+}
+
+// handleDeleteRecipient is handler for DELETE /api/recipients/:id
+func (s *Server) handleDeleteRecipient(rw http.ResponseWriter, r *http.Request) (any, int) {
+	id := r.PathValue("id")
+
+	if s.getRecipient(id) == nil {
+		return errorResponse("Not found"), 404
+	}
+
+	s.recipients = slices.DeleteFunc(s.recipients, func(rr *updown.Recipient) bool {
+		return rr.ID == id
+	})
+
+	for _, c := range s.checks {
+		c.Recipients = slices.DeleteFunc(c.Recipients, func(rid string) bool {
+			return rid == id
+		})
+	}
+
+	return map[string]bool{"deleted": true}, 200
+}
+
+// handleGetStatusPages is handler for GET /api/status-pages
+func (s *Server) handleGetStatusPages(rw http.ResponseWriter, r *http.Request) (any, int) {
+	if s.statusPages == nil {
+		return updown.StatusPages{}, 200
+	}
+
+	return s.statusPages, 200
+}
+
+// handleCreateStatusPage is handler for POST /api/status-pages
+func (s *Server) handleCreateStatusPage(rw http.ResponseWriter, r *http.Request) (any, int) {
+	options := &updown.StatusPage{}
+
+	if json.NewDecoder(r.Body).Decode(options) != nil {
+		return errorResponse("Invalid request body"), 400
+	}
+
+	if len(options.Checks) == 0 {
+		return errorResponse("Checks are required"), 400
+	}
+
+	page := &updown.StatusPage{
+		Token:      s.genStatusPageToken(),
+		Visibility: "public",
+	}
+
+	page.URL = "https://updown.io/p/" + page.Token
+
+	applyStatusPageOptions(page, options)
+
+	s.statusPages = append(s.statusPages, page)
+
+	return page, 201
+}
+
+// handleUpdateStatusPage is handler for PUT /api/status-pages/:token
+func (s *Server) handleUpdateStatusPage(rw http.ResponseWriter, r *http.Request) (any, int) {
+	page := s.getStatusPage(r.PathValue("token"))
+
+	if page == nil {
+		return errorResponse("Not found"), 404
+	}
+
+	options := &updown.StatusPage{}
+
+	if json.NewDecoder(r.Body).Decode(options) != nil {
+		return errorResponse("Invalid request body"), 400
+	}
+
+	applyStatusPageOptions(page, options)
+
+	return page, 200
+}
+
+// handleDeleteStatusPage is handler for DELETE /api/status-pages/:token
+func (s *Server) handleDeleteStatusPage(rw http.ResponseWriter, r *http.Request) (any, int) {
+	token := r.PathValue("token")
+
+	if s.getStatusPage(token) == nil {
+		return errorResponse("Not found"), 404
+	}
+
+	s.statusPages = slices.DeleteFunc(s.statusPages, func(p *updown.StatusPage) bool {
+		return p.Token == token
+	})
+
+	return map[string]bool{"deleted": true}, 200
+}
+
+// handlePulse is handler for pulse requests
+func (s *Server) handlePulse(rw http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	latency := s.latency
+	statusCode := s.getInjectedError(r.Method, "/pulse")
+	s.mu.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	if statusCode != 0 {
+		rw.WriteHeader(statusCode)
+		return
+	}
+
+	payload, _ := io.ReadAll(r.Body)
+
+	p := &Pulse{
+		Token:   r.PathValue("token"),
+		Key:     r.PathValue("key"),
+		UUID:    genUUID(),
+		Payload: string(payload),
+		Time:    time.Now(),
+	}
+
+	s.mu.Lock()
+
+	s.pulses = append(s.pulses, p)
+
+	if check := s.checks.Get(p.Token); check != nil {
+		check.LastCheckAt = updown.Date{Time: p.Time.UTC().Truncate(time.Second)}
+	}
+
+	s.mu.Unlock()
+
+	rw.WriteHeader(200)
+	rw.Write([]byte("OK: " + p.UUID))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getInjectedError returns injected status code for given request
+func (s *Server) getInjectedError(method, route string) int {
+	for _, key := range []string{method + " " + route, route, ROUTE_ALL} {
+		if code, ok := s.errors[key]; ok {
+			return code
+		}
+	}
+
+	return 0
+}
+
+// newEvent creates basic webhook event
+func (s *Server) newEvent(typ string, check *updown.Check, now time.Time) updown.Event {
+	cc := *check
+	cc.Metrics = nil
+
+	return updown.Event{
+		Type:        typ,
+		Time:        updown.Date{Time: now},
+		Description: fmt.Sprintf("%s %s", check.URL, strings.TrimPrefix(typ, "check.")),
+		Check:       &cc,
+	}
+}
+
+// getRecipient returns recipient with given ID
+func (s *Server) getRecipient(id string) *updown.Recipient {
+	for _, r := range s.recipients {
+		if r.ID == id {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// getStatusPage returns status page with given token
+func (s *Server) getStatusPage(token string) *updown.StatusPage {
+	for _, p := range s.statusPages {
+		if p.Token == token {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// genToken generates unique check token
+func (s *Server) genToken() string {
+	for {
+		token := randomHex(2)
+
+		if s.checks.Get(token) == nil {
+			return token
+		}
+	}
+}
+
+// genID generates downtime ID
+func (s *Server) genID() string {
+	return randomHex(12)
+}
+
+// genRecipientID generates unique recipient ID
+func (s *Server) genRecipientID(typ string) string {
+	for {
+		id := typ + ":" + randomHex(5)
+
+		if s.getRecipient(id) == nil {
+			return id
+		}
+	}
+}
+
+// genStatusPageToken generates unique status page token
+func (s *Server) genStatusPageToken() string {
+	for {
+		token := randomHex(3)
+
+		if s.getStatusPage(token) == nil {
+			return token
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// applyOptions applies check options to check
+func applyOptions(check *updown.Check, options updown.CheckOptions) {
+	if options.URL != "" {
+		check.URL = options.URL
+	}
+
+	if options.Alias != "" {
+		check.Alias = options.Alias
+	}
+
+	if options.Period != 0 {
+		check.Period = options.Period
+	}
+
+	if options.Apdex != 0 {
+		check.Apdex = options.Apdex
+	}
+
+	if options.IsEnabled != nil {
+		check.IsEnabled = *options.IsEnabled
+	}
+
+	if options.IsPublished != nil {
+		check.IsPublished = *options.IsPublished
+	}
+
+	if options.MuteUntil != nil {
+		mute, _ := time.Parse(time.RFC3339, *options.MuteUntil)
+		check.MuteUntil = updown.Date{Time: mute.UTC()}
+	}
+
+	if options.StringMatch != "" {
+		check.StringMatch = options.StringMatch
+	}
+
+	if options.HTTPVerb != "" {
+		check.HTTPVerb = options.HTTPVerb
+	}
+
+	if options.HTTPBody != "" {
+		check.HTTPBody = options.HTTPBody
+	}
+
+	if options.Recipients != nil {
+		check.Recipients = options.Recipients
+	}
+
+	if options.DisabledLocations != nil {
+		check.DisabledLocations = options.DisabledLocations
+	}
+
+	if options.CustomHeaders != nil {
+		check.CustomHeaders = options.CustomHeaders
+	}
+}
+
+// applyStatusPageOptions applies status page options to status page
+func applyStatusPageOptions(page, options *updown.StatusPage) {
+	if options.Name != "" {
+		page.Name = options.Name
+	}
+
+	if options.Description != "" {
+		page.Description = options.Description
+	}
+
+	if options.Visibility != "" {
+		page.Visibility = options.Visibility
+	}
+
+	if options.AccessKey != "" {
+		page.AccessKey = options.AccessKey
+	}
+
+	if options.Checks != nil {
+		page.Checks = options.Checks
+	}
+}
+
+// sendWebhook sends webhook with given event
+func sendWebhook(url string, event any) error {
+	if url == "" {
+		return nil
+	}
+
+	data, err := json.Marshal([]any{event})
+
+	if err != nil {
+		return fmt.Errorf("Can't encode webhook: %w", err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+
+	if err != nil {
+		return fmt.Errorf("Can't send webhook: %w", err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode > 299 {
+		return fmt.Errorf("Webhook receiver returned status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// writeJSON writes JSON response
+func writeJSON(rw http.ResponseWriter, statusCode int, data any) {
+	writeBody(rw, statusCode, encodeJSON(data))
+}
+
+// writeBody writes encoded JSON response
+func writeBody(rw http.ResponseWriter, statusCode int, body []byte) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(statusCode)
+	rw.Write(body)
+}
+
+// encodeJSON encodes given data to JSON
+func encodeJSON(data any) []byte {
+	var buf bytes.Buffer
+
+	json.NewEncoder(&buf).Encode(data)
+
+	return buf.Bytes()
+}
+
+// errorResponse creates API error response
+func errorResponse(msg string) map[string]string {
+	return map[string]string{"error": msg}
+}
+
+// getRoute returns API route for given request
+func getRoute(r *http.Request) string {
+	path := strings.TrimPrefix(r.URL.Path, "/api")
+
+	if token := r.PathValue("token"); token != "" {
+		return strings.Replace(path, "/"+token, "/:token", 1)
+	}
+
+	if id := r.PathValue("id"); id != "" {
+		return strings.Replace(path, "/"+id, "/:id", 1)
+	}
+
+	return path
+}
+
+// getNodesNames returns sorted slice with nodes names
+func getNodesNames(nodes updown.Nodes) []string {
+	var result []string
+
+	for name := range nodes {
+		result = append(result, name)
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// genUUID generates random UUID (v4)
+func genUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	h := hex.EncodeToString(b)
+
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// randomHex generates random hex string with given number of bytes
+func randomHex(size int) string {
+	b := make([]byte, size)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
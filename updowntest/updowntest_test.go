@@ -0,0 +1,479 @@
+package updowntest
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/essentialkaos/updown"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+type UpdowntestSuite struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&UpdowntestSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpdowntestSuite) TestChecks(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	token := srv.AddCheck(&updown.Check{URL: "https://domain.com", Alias: "Test"})
+	c.Assert(token, HasLen, 4)
+	c.Assert(srv.AddCheck(&updown.Check{Token: "abcd", URL: "https://abcd.com"}), Equals, "abcd")
+
+	srv.SetMetrics(token, &updown.Metrics{Uptime: 99.5})
+
+	client := srv.Client()
+
+	checks, err := client.GetChecks()
+	c.Assert(err, IsNil)
+	c.Assert(checks, HasLen, 2)
+	c.Assert(checks[0].Token, Equals, token)
+	c.Assert(checks[0].Alias, Equals, "Test")
+	c.Assert(checks[0].Metrics, IsNil)
+
+	check, err := client.GetCheck(token, true)
+	c.Assert(err, IsNil)
+	c.Assert(check.Metrics, NotNil)
+	c.Assert(check.Metrics.Uptime, Equals, 99.5)
+
+	_, err = client.GetCheck("0000", false)
+	c.Assert(err, NotNil)
+
+	enabled := false
+	check, err = client.CreateCheck(updown.CheckOptions{
+		URL: "https://new.com", Period: 300, IsEnabled: &enabled,
+	})
+	c.Assert(err, IsNil)
+	c.Assert(check.Token, Not(Equals), "")
+	c.Assert(check.Period, Equals, 300)
+	c.Assert(check.IsEnabled, Equals, false)
+
+	_, err = client.CreateCheck(updown.CheckOptions{Alias: "Test"})
+	c.Assert(err, NotNil)
+
+	mute := "2030-01-01T00:00:00Z"
+	check, err = client.UpdateCheck("abcd", updown.CheckOptions{
+		Alias: "ABCD", MuteUntil: &mute,
+	})
+	c.Assert(err, IsNil)
+	c.Assert(check.Alias, Equals, "ABCD")
+	c.Assert(check.URL, Equals, "https://abcd.com")
+	c.Assert(srv.Check("abcd").MuteUntil.Year(), Equals, 2030)
+
+	c.Assert(client.DeleteCheck("abcd"), IsNil)
+	c.Assert(client.DeleteCheck("abcd"), NotNil)
+	c.Assert(srv.Check("abcd"), IsNil)
+
+	c.Assert(srv.Requests(), Equals, 7)
+}
+
+func (s *UpdowntestSuite) TestConcurrentAccess(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	token := srv.AddCheck(&updown.Check{URL: "https://domain.com"})
+	srv.SetMetrics(token, &updown.Metrics{Uptime: 99.5})
+	srv.AddRecipient(&updown.Recipient{Type: "email", Value: "john@domain.com"})
+
+	client := srv.Client()
+	stop, done := make(chan struct{}), make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				srv.CheckDown(token, "Timeout")
+				srv.CheckUp(token)
+				srv.SetMetrics(token, &updown.Metrics{Uptime: 99})
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}()
+
+	alias := "Test"
+
+	for range 20 {
+		_, err := client.UpdateCheck(token, updown.CheckOptions{Alias: alias})
+		c.Assert(err, IsNil)
+		_, err = client.GetChecks()
+		c.Assert(err, IsNil)
+		_, err = client.GetMetrics(token, updown.MetricsOptions{})
+		c.Assert(err, IsNil)
+		_, err = client.GetRecipients()
+		c.Assert(err, IsNil)
+	}
+
+	close(stop)
+	<-done
+}
+
+func (s *UpdowntestSuite) TestDowntimes(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	token := srv.AddCheck(&updown.Check{URL: "https://domain.com"})
+
+	for range 150 {
+		srv.AddDowntime(token, &updown.Downtime{
+			Error:       "500",
+			DownResults: []*updown.DowntimeCheck{{Status: "500"}},
+		})
+	}
+
+	client := srv.Client()
+
+	downtimes, err := client.GetDowntimes(token, false)
+	c.Assert(err, IsNil)
+	c.Assert(downtimes, HasLen, 150)
+	c.Assert(downtimes[0].DownResults, IsNil)
+
+	downtimes, err = client.GetDowntimes(token, true)
+	c.Assert(err, IsNil)
+	c.Assert(downtimes[149].DownResults, HasLen, 1)
+
+	_, err = client.GetDowntimes("0000", false)
+	c.Assert(err, NotNil)
+}
+
+func (s *UpdowntestSuite) TestMetrics(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	token := srv.AddCheck(&updown.Check{URL: "https://domain.com"})
+	now := time.Now().UTC().Truncate(time.Hour)
+
+	srv.SetMetricsByTime(token, updown.MetricsSeries{
+		{Time: now.Add(-time.Hour), Metrics: &updown.Metrics{Uptime: 100}},
+		{Time: now, Metrics: &updown.Metrics{Uptime: 50}},
+	})
+
+	srv.SetMetricsByHost(token, updown.HostsMetrics{
+		"fra": &updown.Metrics{Uptime: 100},
+	})
+
+	client := srv.Client()
+
+	metrics, err := client.GetMetrics(token, updown.MetricsOptions{})
+	c.Assert(err, IsNil)
+	c.Assert(metrics, NotNil)
+
+	series, err := client.GetMetricsByTime(token, updown.MetricsOptions{})
+	c.Assert(err, IsNil)
+	c.Assert(series, HasLen, 2)
+	c.Assert(series[1].Time.Equal(now), Equals, true)
+	c.Assert(series[1].Metrics.Uptime, Equals, 50.0)
+
+	hosts, err := client.GetMetricsByHost(token, updown.MetricsOptions{})
+	c.Assert(err, IsNil)
+	c.Assert(hosts["fra"], NotNil)
+}
+
+func (s *UpdowntestSuite) TestOtherResources(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.SetNodes(updown.Nodes{
+		"fra": &updown.Node{IP: "1.1.1.1", IPv6: "::1"},
+		"lan": &updown.Node{IP: "2.2.2.2"},
+	})
+
+	srv.AddRecipient(&updown.Recipient{ID: "email:1", Name: "John"})
+	srv.AddStatusPage(&updown.StatusPage{Token: "abcd"})
+
+	client := srv.Client()
+
+	nodes, err := client.GetNodes()
+	c.Assert(err, IsNil)
+	c.Assert(nodes, HasLen, 2)
+
+	ips, err := client.GetNodesIPs()
+	c.Assert(err, IsNil)
+	c.Assert(ips, DeepEquals, []string{"1.1.1.1", "::1", "2.2.2.2"})
+
+	ips, err = client.GetNodesIPsV4()
+	c.Assert(err, IsNil)
+	c.Assert(ips, DeepEquals, []string{"1.1.1.1", "2.2.2.2"})
+
+	ips, err = client.GetNodesIPsV6()
+	c.Assert(err, IsNil)
+	c.Assert(ips, DeepEquals, []string{"::1"})
+
+	recipients, err := client.GetRecipients()
+	c.Assert(err, IsNil)
+	c.Assert(recipients, HasLen, 1)
+
+	pages, err := client.GetStatusPages()
+	c.Assert(err, IsNil)
+	c.Assert(pages, HasLen, 1)
+}
+
+func (s *UpdowntestSuite) TestRecipientsWrites(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	token := srv.AddCheck(&updown.Check{URL: "https://domain.com", Recipients: []string{"email:1"}})
+	srv.AddRecipient(&updown.Recipient{ID: "email:1", Type: "email", Value: "john@domain.com"})
+
+	status, body := sendRequest(srv, "POST", "/recipients", `{"type":"slack","value":"https://hooks.slack.com/1","name":"Ops"}`)
+	c.Assert(status, Equals, 201)
+
+	recipient := &updown.Recipient{}
+	c.Assert(json.Unmarshal(body, recipient), IsNil)
+	c.Assert(recipient.ID, Matches, "slack:[0-9a-f]{10}")
+	c.Assert(recipient.Name, Equals, "Ops")
+
+	status, _ = sendRequest(srv, "POST", "/recipients", `{"type":"email"}`)
+	c.Assert(status, Equals, 400)
+	status, _ = sendRequest(srv, "POST", "/recipients", `{`)
+	c.Assert(status, Equals, 400)
+
+	status, _ = sendRequest(srv, "PUT", "/recipients/"+recipient.ID, `{"name":"Support","value":"https://hooks.slack.com/2"}`)
+	c.Assert(status, Equals, 200)
+	c.Assert(srv.Recipient(recipient.ID).Name, Equals, "Support")
+	c.Assert(srv.Recipient(recipient.ID).Value, Equals, "https://hooks.slack.com/2")
+
+	status, _ = sendRequest(srv, "PUT", "/recipients/email:0", `{"name":"Test"}`)
+	c.Assert(status, Equals, 404)
+	status, _ = sendRequest(srv, "PUT", "/recipients/"+recipient.ID, `{`)
+	c.Assert(status, Equals, 400)
+
+	srv.InjectError("DELETE /recipients/:id", 503)
+	status, _ = sendRequest(srv, "DELETE", "/recipients/email:1", "")
+	c.Assert(status, Equals, 503)
+	srv.ClearErrors()
+
+	status, _ = sendRequest(srv, "DELETE", "/recipients/email:1", "")
+	c.Assert(status, Equals, 200)
+	status, _ = sendRequest(srv, "DELETE", "/recipients/email:1", "")
+	c.Assert(status, Equals, 404)
+
+	c.Assert(srv.Recipient("email:1"), IsNil)
+	c.Assert(srv.Check(token).Recipients, HasLen, 0)
+
+	recipients, err := srv.Client().GetRecipients()
+	c.Assert(err, IsNil)
+	c.Assert(recipients, HasLen, 1)
+}
+
+func (s *UpdowntestSuite) TestStatusPagesWrites(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	status, body := sendRequest(srv, "POST", "/status-pages", `{"name":"Status","checks":["ngg8"]}`)
+	c.Assert(status, Equals, 201)
+
+	page := &updown.StatusPage{}
+	c.Assert(json.Unmarshal(body, page), IsNil)
+	c.Assert(page.Token, HasLen, 6)
+	c.Assert(page.URL, Equals, "https://updown.io/p/"+page.Token)
+	c.Assert(page.Visibility, Equals, "public")
+
+	status, _ = sendRequest(srv, "POST", "/status-pages", `{"name":"Status"}`)
+	c.Assert(status, Equals, 400)
+	status, _ = sendRequest(srv, "POST", "/status-pages", `{`)
+	c.Assert(status, Equals, 400)
+
+	status, _ = sendRequest(srv, "PUT", "/status-pages/"+page.Token,
+		`{"description":"Test","visibility":"protected","access_key":"abcd","checks":["ngg8","abcd"]}`,
+	)
+
+	c.Assert(status, Equals, 200)
+
+	page = srv.StatusPage(page.Token)
+
+	c.Assert(page.Name, Equals, "Status")
+	c.Assert(page.Description, Equals, "Test")
+	c.Assert(page.Visibility, Equals, "protected")
+	c.Assert(page.AccessKey, Equals, "abcd")
+	c.Assert(page.Checks, DeepEquals, []string{"ngg8", "abcd"})
+
+	status, _ = sendRequest(srv, "PUT", "/status-pages/0000", `{"name":"Test"}`)
+	c.Assert(status, Equals, 404)
+	status, _ = sendRequest(srv, "PUT", "/status-pages/"+page.Token, `{`)
+	c.Assert(status, Equals, 400)
+
+	status, _ = sendRequest(srv, "DELETE", "/status-pages/"+page.Token, "")
+	c.Assert(status, Equals, 200)
+	status, _ = sendRequest(srv, "DELETE", "/status-pages/"+page.Token, "")
+	c.Assert(status, Equals, 404)
+
+	c.Assert(srv.StatusPage(page.Token), IsNil)
+
+	pages, err := srv.Client().GetStatusPages()
+	c.Assert(err, IsNil)
+	c.Assert(pages, HasLen, 0)
+}
+
+func (s *UpdowntestSuite) TestErrors(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	token := srv.AddCheck(&updown.Check{URL: "https://domain.com"})
+	client := srv.Client()
+
+	srv.InjectError("/checks/:token", 500)
+
+	_, err := client.GetCheck(token, false)
+	c.Assert(err, NotNil)
+	_, err = client.GetChecks()
+	c.Assert(err, IsNil)
+
+	srv.InjectError("/checks/:token", 0)
+	srv.InjectError("DELETE /checks/:token", 503)
+
+	_, err = client.GetCheck(token, false)
+	c.Assert(err, IsNil)
+	c.Assert(client.DeleteCheck(token), NotNil)
+
+	srv.InjectError(ROUTE_ALL, 429)
+
+	_, err = client.GetNodes()
+	c.Assert(err, NotNil)
+
+	srv.ClearErrors()
+
+	_, err = client.GetNodes()
+	c.Assert(err, IsNil)
+
+	srv.SetLatency(50 * time.Millisecond)
+
+	start := time.Now()
+	_, err = client.GetNodes()
+	c.Assert(err, IsNil)
+	c.Assert(time.Since(start) >= 50*time.Millisecond, Equals, true)
+
+	srv.SetLatency(0)
+
+	badClient, _ := updown.NewClient("invalid")
+	badClient.SetURL(srv.URL)
+
+	_, err = badClient.GetChecks()
+	c.Assert(err, NotNil)
+
+	c.Assert(srv.CheckDown("0000", "500"), Equals, ErrUnknownCheck)
+	c.Assert(srv.CheckUp("0000"), Equals, ErrUnknownCheck)
+
+	var nilServer *Server
+	nilServer.Close()
+}
+
+func (s *UpdowntestSuite) TestWebhooks(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	var events updown.Webhook
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		wh, err := updown.ParseWebhook(data)
+
+		if err == nil {
+			events = append(events, wh...)
+		}
+	}))
+
+	defer receiver.Close()
+
+	token := srv.AddCheck(&updown.Check{URL: "https://domain.com"})
+
+	c.Assert(srv.CheckDown(token, "Timeout"), IsNil)
+	c.Assert(events, HasLen, 0)
+
+	srv.SetWebhookURL(receiver.URL)
+
+	c.Assert(srv.CheckDown(token, "500 Internal Server Error"), IsNil)
+	c.Assert(srv.Check(token).IsDown, Equals, true)
+	c.Assert(srv.CheckUp(token), IsNil)
+	c.Assert(srv.Check(token).IsDown, Equals, false)
+
+	c.Assert(events, HasLen, 2)
+	c.Assert(events[0].Type, Equals, updown.EVENT_DOWN)
+	c.Assert(events[1].Type, Equals, updown.EVENT_UP)
+
+	down := events[0].Event.(*updown.EventDown)
+	c.Assert(down.Check.Token, Equals, token)
+	c.Assert(down.Downtime.Error, Equals, "500 Internal Server Error")
+
+	up := events[1].Event.(*updown.EventUp)
+	c.Assert(up.Downtime.EndedAt.IsZero(), Equals, false)
+
+	downtimes, err := srv.Client().GetDowntimes(token, false)
+	c.Assert(err, IsNil)
+	c.Assert(downtimes, HasLen, 2)
+
+	srv.SetWebhookURL("http://127.0.0.1:1")
+	c.Assert(srv.CheckDown(token, "500"), NotNil)
+}
+
+func (s *UpdowntestSuite) TestPulse(c *C) {
+	srv := NewServer()
+	defer srv.Close()
+
+	token := srv.AddCheck(&updown.Check{URL: "pulse"})
+	client := updown.NewPulseClient()
+	client.Retries = 0
+
+	result, err := client.Send(srv.PulseURL(token, "key1"), "")
+	c.Assert(err, IsNil)
+	c.Assert(result.UUID, HasLen, 36)
+
+	_, err = client.Send(srv.PulseURL(token, "key1"), `{"exit_code":1}`)
+	c.Assert(err, IsNil)
+
+	pulses := srv.Pulses(token)
+	c.Assert(pulses, HasLen, 2)
+	c.Assert(pulses[0].UUID, Equals, result.UUID)
+	c.Assert(pulses[0].Key, Equals, "key1")
+	c.Assert(pulses[1].Payload, Equals, `{"exit_code":1}`)
+	c.Assert(srv.Check(token).LastCheckAt.IsZero(), Equals, false)
+
+	srv.InjectError("/pulse", 500)
+
+	_, err = client.Send(srv.PulseURL(token, "key1"), "")
+	c.Assert(err, NotNil)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sendRequest sends raw API request to server
+func sendRequest(srv *Server, method, endpoint, body string) (int, []byte) {
+	r, _ := http.NewRequest(method, srv.URL+endpoint, strings.NewReader(body))
+	r.Header.Set("X-API-Key", srv.APIKey)
+
+	resp, err := http.DefaultClient.Do(r)
+
+	if err != nil {
+		return 0, nil
+	}
+
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+
+	return resp.StatusCode, data
+}